@@ -18,8 +18,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/toodofun/gvm/languages/github"
-
 	"github.com/spf13/cobra"
 
 	"github.com/toodofun/gvm/internal/core"
@@ -41,21 +39,20 @@ func NewAddAddonCmd() *cobra.Command {
 				return fmt.Errorf("language %s already exists, please use a different name", args[1])
 			}
 			config := core.GetConfig()
-			var err error
-			switch args[0] {
-			case "github":
-				_, err = github.NewGithub(args[1], args[2])
-			default:
-				err = fmt.Errorf("unsupported addon type: %s", args[0])
-			}
-			if err != nil {
-				return err
-			}
-			config.Addon = append(config.Addon, core.LanguageItem{
+			item := core.LanguageItem{
 				Provider:       args[0],
 				Name:           args[1],
 				DataSourceName: args[2],
-			})
+			}
+			for _, addon := range config.Addon {
+				if addon.Name == item.Name {
+					return fmt.Errorf("addon %s already exists, please use gvm addon edit", item.Name)
+				}
+			}
+			if _, err := core.NewAddon(item); err != nil {
+				return err
+			}
+			config.Addon = append(config.Addon, item)
 			return file.WriteJSONFile(core.GetConfigPath(), config)
 		},
 	}
@@ -0,0 +1,284 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/color"
+	"github.com/toodofun/gvm/internal/util/file"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// addonRecord gvm addon ls 的输出，Error 为插件无法加载的原因
+type addonRecord struct {
+	Name     string `json:"name" yaml:"name"`
+	Provider string `json:"provider" yaml:"provider"`
+	DSN      string `json:"dsn" yaml:"dsn"`
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// addonTestRecord gvm addon test 的输出
+type addonTestRecord struct {
+	Name     string      `json:"name" yaml:"name"`
+	Versions int         `json:"versions" yaml:"versions"`
+	Version  string      `json:"version" yaml:"version"`
+	Platform string      `json:"platform" yaml:"platform"`
+	Asset    *core.Asset `json:"asset,omitempty" yaml:"asset,omitempty"`
+	// Rejected 其余安装包未被选择的原因
+	Rejected []core.AssetRejection `json:"rejected,omitempty" yaml:"rejected,omitempty"`
+}
+
+func NewAddonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "addon",
+		Short: "Manage addons added by gvm add",
+		Example: "  gvm addon ls\n" +
+			"  gvm addon test kubectl\n" +
+			"  gvm addon edit kubectl --dsn kubernetes/kubernetes\n" +
+			"  gvm addon rm kubectl",
+	}
+	cmd.AddCommand(newAddonLsCmd(), newAddonRmCmd(), newAddonEditCmd(), newAddonTestCmd())
+	return cmd
+}
+
+// findAddon 返回配置中指定名称的插件下标
+func findAddon(config *core.Config, name string) (int, error) {
+	for i, item := range config.Addon {
+		if item.Name == name {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("addon %s does not exist", name)
+}
+
+// addonError 返回插件无法加载的原因，启动时没有加载的插件（如刚修改过配置）会重新创建一次
+func addonError(item core.LanguageItem) error {
+	if err, ok := core.GetAddonErrors()[item.Name]; ok {
+		return err
+	}
+	if _, exists := core.GetLanguage(item.Name); exists {
+		return nil
+	}
+	_, err := core.NewAddon(item)
+	return err
+}
+
+func newAddonLsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ls",
+		Short: "List addons and whether they loaded",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkOutput(); err != nil {
+				return err
+			}
+			records := make([]*addonRecord, 0)
+			for _, item := range core.GetConfig().Addon {
+				r := &addonRecord{Name: item.Name, Provider: item.Provider, DSN: item.DataSourceName}
+				if err := addonError(item); err != nil {
+					r.Error = err.Error()
+				}
+				records = append(records, r)
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case structuredOutput():
+				return writeStructured(out, records)
+			case output == outputPlain:
+				for _, r := range records {
+					_, _ = fmt.Fprintln(out, r.Name)
+				}
+				return nil
+			}
+			t := newTableWriter(out)
+			t.AppendHeader(table.Row{"Name", "Provider", "DSN", "Status"})
+			for _, r := range records {
+				status := color.GreenFont("ok")
+				if r.Error != "" {
+					status = color.RedFont(r.Error)
+				}
+				t.AppendRow(table.Row{r.Name, r.Provider, r.DSN, status})
+			}
+			t.Render()
+			return nil
+		},
+	}
+}
+
+func newAddonRmCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rm <name>",
+		Short: "Remove an addon, installed versions are kept",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("requires one argument: <name>")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := core.GetConfig()
+			i, err := findAddon(config, args[0])
+			if err != nil {
+				return err
+			}
+			config.Addon = append(config.Addon[:i], config.Addon[i+1:]...)
+			return file.WriteJSONFile(core.GetConfigPath(), config)
+		},
+	}
+}
+
+func newAddonEditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit <name>",
+		Short: "Change the provider, name or data source of an addon",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("requires one argument: <name>")
+			}
+			return nil
+		},
+	}
+
+	var name, provider, dsn string
+	cmd.Flags().StringVar(&name, "name", "", "New addon name")
+	cmd.Flags().StringVar(&provider, "provider", "", "New addon provider")
+	cmd.Flags().StringVar(&dsn, "dsn", "", "New data source name")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		config := core.GetConfig()
+		i, err := findAddon(config, args[0])
+		if err != nil {
+			return err
+		}
+		item := config.Addon[i]
+		if name != "" && name != item.Name {
+			if _, err := findAddon(config, name); err == nil {
+				return fmt.Errorf("addon %s already exists, please use a different name", name)
+			}
+			if lang, exists := core.GetLanguage(name); exists && !isAddon(config, lang.Name()) {
+				return fmt.Errorf("language %s already exists, please use a different name", name)
+			}
+			item.Name = name
+		}
+		if provider != "" {
+			item.Provider = provider
+		}
+		if dsn != "" {
+			item.DataSourceName = dsn
+		}
+		if _, err := core.NewAddon(item); err != nil {
+			return err
+		}
+		config.Addon[i] = item
+		return file.WriteJSONFile(core.GetConfigPath(), config)
+	}
+	return cmd
+}
+
+// isAddon 判断已注册的语言是否来自插件
+func isAddon(config *core.Config, name string) bool {
+	_, err := findAddon(config, name)
+	return err == nil
+}
+
+func newAddonTestCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "test <name> [<version>]",
+		Short: "Resolve releases and the asset for this platform without installing",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || len(args) > 2 {
+				return fmt.Errorf("requires one or two arguments: <name> [<version>]")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if err := checkOutput(); err != nil {
+				return err
+			}
+			config := core.GetConfig()
+			i, err := findAddon(config, args[0])
+			if err != nil {
+				return err
+			}
+			// 直接按配置创建，不依赖启动时的注册结果，修改配置后可以立即验证
+			language, err := core.NewAddon(config.Addon[i])
+			if err != nil {
+				return err
+			}
+			version := "latest"
+			if len(args) > 1 {
+				version = args[1]
+			}
+			spec, err := remoteSpec(language, version)
+			if err != nil {
+				return err
+			}
+			// 只列出一次发布版本，选择安装包时复用同一份结果
+			versions, err := language.ListRemoteVersions(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list releases: %w", err)
+			}
+			rv, err := matchRemote(spec, versions)
+			if err != nil {
+				return err
+			}
+
+			record := &addonTestRecord{
+				Name:     language.Name(),
+				Versions: len(versions),
+				Version:  rv.Version.String(),
+				Platform: runtime.GOOS + "/" + runtime.GOARCH,
+			}
+			var resolveErr error
+			if resolver, ok := language.(core.AssetResolver); ok {
+				record.Asset, record.Rejected, resolveErr = resolver.ResolveAsset(ctx, rv)
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case structuredOutput():
+				if err := writeStructured(out, record); err != nil {
+					return err
+				}
+				return resolveErr
+			case output == outputPlain:
+				if record.Asset != nil {
+					_, _ = fmt.Fprintln(out, record.Asset.URL)
+				}
+				return resolveErr
+			}
+			_, _ = fmt.Fprintf(out, "%d releases found, %s resolves to %s\n", record.Versions, version, record.Version)
+			if record.Asset != nil {
+				_, _ = fmt.Fprintf(out, "asset for %s: %s\n  %s\n", record.Platform, record.Asset.Name, record.Asset.URL)
+			}
+			if len(record.Rejected) > 0 {
+				t := newTableWriter(out)
+				t.AppendHeader(table.Row{"Rejected asset", "Reason"})
+				for _, r := range record.Rejected {
+					t.AppendRow(table.Row{r.Name, r.Reason})
+				}
+				t.Render()
+			}
+			return resolveErr
+		},
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/testutil"
+	"github.com/toodofun/gvm/internal/util/asset"
+	"github.com/toodofun/gvm/internal/util/file"
+	"github.com/toodofun/gvm/languages/release"
+)
+
+func runAddon(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	c := NewAddonCmd()
+	c.SetArgs(args)
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetErr(buf)
+	err := c.Execute()
+	return buf.String(), err
+}
+
+func TestAddon(t *testing.T) {
+	testutil.SetRootDir(t)
+	core.RegisterAddonProvider("fake", func(name, dsn string) (core.Language, error) {
+		if dsn != "owner/repo" {
+			return nil, fmt.Errorf("repository %s not found", dsn)
+		}
+		return &installedLanguage{}, nil
+	})
+	require.NoError(t, file.WriteJSONFile(core.GetConfigPath(), &core.Config{Addon: []core.LanguageItem{
+		{Name: "tool", Provider: "fake", DataSourceName: "owner/typo"},
+	}}))
+
+	out, err := runAddon(t, "ls")
+	require.NoError(t, err)
+	assert.Contains(t, out, "repository owner/typo not found")
+
+	_, err = runAddon(t, "edit", "tool", "--dsn", "other/typo")
+	assert.EqualError(t, err, "repository other/typo not found")
+	_, err = runAddon(t, "edit", "tool", "--dsn", "owner/repo", "--name", "mytool")
+	require.NoError(t, err)
+	assert.Equal(t, []core.LanguageItem{{Name: "mytool", Provider: "fake", DataSourceName: "owner/repo"}},
+		core.GetConfig().Addon)
+
+	out, err = runAddon(t, "ls")
+	require.NoError(t, err)
+	assert.Contains(t, out, "ok")
+
+	_, err = runAddon(t, "rm", "tool")
+	assert.EqualError(t, err, "addon tool does not exist")
+	_, err = runAddon(t, "rm", "mytool")
+	require.NoError(t, err)
+	assert.Empty(t, core.GetConfig().Addon)
+}
+
+// countingSource 记录请求发布版本的次数
+type countingSource struct {
+	calls int
+}
+
+func (c *countingSource) ListReleases(ctx context.Context) ([]*release.Release, error) {
+	c.calls++
+	name := fmt.Sprintf("tool_%s_%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	return []*release.Release{
+		{Name: "v1.1.0", Assets: []core.Asset{{Name: name, URL: "https://example.com/v1.1.0/" + name}}},
+		{Name: "v1.2.0", Assets: []core.Asset{{Name: name, URL: "https://example.com/v1.2.0/" + name}}},
+	}, nil
+}
+
+func TestAddonTest(t *testing.T) {
+	testutil.SetRootDir(t)
+	source := &countingSource{}
+	core.RegisterAddonProvider("counting", func(name, dsn string) (core.Language, error) {
+		return release.NewAddon(name, "tool", source, asset.DefaultRules()), nil
+	})
+	require.NoError(t, file.WriteJSONFile(core.GetConfigPath(), &core.Config{Addon: []core.LanguageItem{
+		{Name: "tool", Provider: "counting", DataSourceName: "owner/tool"},
+	}}))
+
+	out, err := runAddon(t, "test", "tool")
+	require.NoError(t, err)
+	assert.Contains(t, out, "2 releases found, latest resolves to 1.2.0")
+	assert.Contains(t, out, "https://example.com/v1.2.0/")
+	assert.Equal(t, 1, source.calls, "releases are listed once and reused to resolve the asset")
+}
@@ -0,0 +1,201 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/color"
+	"github.com/toodofun/gvm/internal/util/file"
+	"github.com/toodofun/gvm/internal/util/match"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// aliasRecord gvm alias ls 的输出，Version 为别名当前解析到的已安装版本
+type aliasRecord struct {
+	Language string `json:"language" yaml:"language"`
+	Name     string `json:"name" yaml:"name"`
+	Target   string `json:"target" yaml:"target"`
+	Version  string `json:"version,omitempty" yaml:"version,omitempty"`
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func NewAliasCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage version aliases",
+		Example: "  gvm alias set go work 1.21.9\n" +
+			"  gvm use go work\n" +
+			"  gvm alias ls",
+	}
+	cmd.AddCommand(newAliasSetCmd(), newAliasRmCmd(), newAliasLsCmd())
+	return cmd
+}
+
+func newAliasSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <lang> <name> <version>",
+		Short: "Create or update an alias for a version",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 3 {
+				return fmt.Errorf("requires three arguments: <lang> <name> <version>")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			language, exists := core.GetLanguage(args[0])
+			if !exists {
+				return cmd.Help()
+			}
+			name, target := args[1], args[2]
+			// 别名不能与版本说明符冲突，否则 1.21、latest 这类写法会产生歧义
+			if _, err := match.ParseSpec(name); err == nil {
+				return fmt.Errorf("alias %s conflicts with a version specifier, please use a different name", name)
+			}
+			if _, err := match.ParseSpec(target); err != nil {
+				return err
+			}
+
+			config := core.GetConfig()
+			if config.Aliases == nil {
+				config.Aliases = make(map[string]map[string]string)
+			}
+			if config.Aliases[language.Name()] == nil {
+				config.Aliases[language.Name()] = make(map[string]string)
+			}
+			config.Aliases[language.Name()][name] = target
+			if err := file.WriteJSONFile(core.GetConfigPath(), config); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s %s -> %s\n", language.Name(), name, target)
+			return nil
+		},
+	}
+}
+
+func newAliasRmCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rm <lang> <name>",
+		Short: "Remove an alias",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("requires two arguments: <lang> <name>")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			language, exists := core.GetLanguage(args[0])
+			if !exists {
+				return cmd.Help()
+			}
+			config := core.GetConfig()
+			if _, ok := config.Aliases[language.Name()][args[1]]; !ok {
+				return fmt.Errorf("alias %s of %s does not exist", args[1], language.Name())
+			}
+			delete(config.Aliases[language.Name()], args[1])
+			if len(config.Aliases[language.Name()]) == 0 {
+				delete(config.Aliases, language.Name())
+			}
+			return file.WriteJSONFile(core.GetConfigPath(), config)
+		},
+	}
+}
+
+func newAliasLsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ls [<lang>]",
+		Short: "List aliases and the installed versions they resolve to",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return fmt.Errorf("accepts at most one argument: [<lang>]")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if err := checkOutput(); err != nil {
+				return err
+			}
+			aliases := core.GetConfig().Aliases
+			langs := make([]string, 0, len(aliases))
+			for lang := range aliases {
+				langs = append(langs, lang)
+			}
+			if len(args) > 0 {
+				langs = args
+			}
+			sort.Strings(langs)
+
+			records := make([]*aliasRecord, 0)
+			for _, lang := range langs {
+				names := make([]string, 0, len(aliases[lang]))
+				for name := range aliases[lang] {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				language, exists := core.GetLanguage(lang)
+				for _, name := range names {
+					r := &aliasRecord{Language: lang, Name: name, Target: aliases[lang][name]}
+					records = append(records, r)
+					if !exists {
+						r.Error = fmt.Sprintf("language %s is not registered", lang)
+						continue
+					}
+					spec, err := parseSpec(language, name)
+					if err != nil {
+						r.Error = err.Error()
+						continue
+					}
+					if spec.IsSystem() {
+						r.Version = match.SystemVersion
+						continue
+					}
+					iv, err := resolveInstalled(ctx, language, spec)
+					if err != nil {
+						r.Error = err.Error()
+						continue
+					}
+					r.Version = iv.Origin
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case structuredOutput():
+				return writeStructured(out, records)
+			case output == outputPlain:
+				for _, r := range records {
+					_, _ = fmt.Fprintf(out, "%s %s %s\n", r.Language, r.Name, r.Version)
+				}
+				return nil
+			}
+			t := newTableWriter(out)
+			t.AppendHeader(table.Row{"Language", "Alias", "Target", "Resolved"})
+			for _, r := range records {
+				resolved := r.Version
+				if r.Error != "" {
+					resolved = color.RedFont(r.Error)
+				}
+				t.AppendRow(table.Row{r.Language, r.Name, r.Target, resolved})
+			}
+			t.Render()
+			return nil
+		},
+	}
+}
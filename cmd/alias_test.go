@@ -0,0 +1,98 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/testutil"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// installedLanguage 只提供已安装版本列表的语言，用于测试版本解析
+type installedLanguage struct {
+	core.Language
+	installed []string
+}
+
+func (l *installedLanguage) Name() string {
+	return "go"
+}
+
+func (l *installedLanguage) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVersion, error) {
+	res := make([]*core.InstalledVersion, 0, len(l.installed))
+	for _, v := range l.installed {
+		res = append(res, &core.InstalledVersion{Version: goversion.Must(goversion.NewVersion(v)), Origin: v})
+	}
+	return res, nil
+}
+
+func runAlias(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	c := NewAliasCmd()
+	c.SetArgs(args)
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetErr(buf)
+	err := c.Execute()
+	return buf.String(), err
+}
+
+func TestAlias(t *testing.T) {
+	testutil.SetRootDir(t)
+	origGetLanguage := core.GetLanguage
+	defer func() { core.GetLanguage = origGetLanguage }()
+	language := &installedLanguage{installed: []string{"1.20.14", "1.21.9", "1.21.13"}}
+	core.GetLanguage = func(name string) (core.Language, bool) {
+		return language, name == "go"
+	}
+
+	_, err := runAlias(t, "set", "go", "work", "1.21.9")
+	require.NoError(t, err)
+	_, err = runAlias(t, "set", "go", "next", "~1.22")
+	require.NoError(t, err)
+	_, err = runAlias(t, "set", "go", "1.21", "1.21.9")
+	assert.Error(t, err, "alias names must not look like versions")
+	assert.Equal(t, map[string]string{"work": "1.21.9", "next": "~1.22"}, core.GetConfig().Aliases["go"])
+
+	spec, err := parseSpec(language, "work")
+	require.NoError(t, err)
+	iv, err := resolveInstalled(context.Background(), language, spec)
+	require.NoError(t, err)
+	assert.Equal(t, "1.21.9", iv.Origin)
+
+	spec, err = parseSpec(language, "next")
+	require.NoError(t, err)
+	_, err = resolveInstalled(context.Background(), language, spec)
+	assert.EqualError(t, err, "alias next points to go ~1.22, which is not installed")
+
+	out, err := runAlias(t, "ls")
+	require.NoError(t, err)
+	assert.Contains(t, out, "work")
+	assert.Contains(t, out, "which is not installed")
+
+	_, err = runAlias(t, "rm", "go", "next")
+	require.NoError(t, err)
+	_, err = runAlias(t, "rm", "go", "next")
+	assert.Error(t, err)
+	assert.Equal(t, map[string]string{"work": "1.21.9"}, core.GetConfig().Aliases["go"])
+}
@@ -16,6 +16,8 @@ package cmd
 
 import (
 	"fmt"
+	"os/exec"
+	"path/filepath"
 
 	"github.com/toodofun/gvm/internal/core"
 
@@ -36,17 +38,47 @@ func NewCurrentCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			lang := args[0]
 			ctx := cmd.Context()
+			if err := checkOutput(); err != nil {
+				return err
+			}
 
 			language, exists := core.GetLanguage(lang)
 			if !exists {
 				return cmd.Help()
 			}
 
+			var record *versionRecord
 			v := language.GetDefaultVersion(ctx)
-			if v.Version.Equal(goversion.Must(goversion.NewVersion("0.0.0"))) {
-				fmt.Println("not set")
-			} else {
-				fmt.Println("version: " + v.Version.String())
+			if !v.Version.Equal(goversion.Must(goversion.NewVersion("0.0.0"))) {
+				record = &versionRecord{
+					Version:   v.Version.String(),
+					Origin:    filepath.Base(v.Location),
+					Installed: true,
+					Current:   true,
+					Location:  v.Location,
+				}
+			} else if p, ok := language.(core.ExecutableProvider); ok {
+				// 未设置默认版本时，报告 PATH 中能找到的系统工具链
+				if bin, err := exec.LookPath(p.Executable()); err == nil {
+					record = &versionRecord{Version: "system", Origin: "system", Current: true, Location: bin}
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case structuredOutput():
+				return writeStructured(out, record)
+			case output == outputPlain:
+				if record != nil {
+					writePlainVersions(out, []*versionRecord{record})
+				}
+				return nil
+			case record == nil:
+				_, _ = fmt.Fprintln(out, "not set")
+			case record.Version == "system":
+				_, _ = fmt.Fprintf(out, "version: system (%s)\n", record.Location)
+			default:
+				_, _ = fmt.Fprintln(out, "version: "+record.Version)
 			}
 			return nil
 		},
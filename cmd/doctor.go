@@ -0,0 +1,112 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/color"
+	"github.com/toodofun/gvm/internal/util/file"
+	"github.com/toodofun/gvm/languages/declarative"
+	"github.com/toodofun/gvm/languages/plugin"
+
+	"github.com/spf13/cobra"
+)
+
+func NewDoctorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the gvm configuration and addons for problems",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			problems := 0
+			report := func(ok bool, format string, a ...any) {
+				if !ok {
+					problems++
+				}
+				printCheck(out, ok, fmt.Sprintf(format, a...))
+			}
+
+			report(true, "root directory: %s", core.GetRootDir())
+
+			// GetConfig 会忽略读取错误，这里单独解析一次以便提示配置文件损坏
+			if _, err := os.Stat(core.GetConfigPath()); err == nil {
+				config := new(core.Config)
+				if err := file.ReadJSONFile(core.GetConfigPath(), config); err != nil {
+					report(false, "config: %v", err)
+				} else {
+					report(true, "config: %s", core.GetConfigPath())
+				}
+			} else {
+				report(true, "config: %s not created yet", core.GetConfigPath())
+			}
+
+			report(true, "languages: %s", strings.Join(core.GetAllLanguage(), ", "))
+
+			for _, item := range core.GetConfig().Addon {
+				if err := addonError(item); err != nil {
+					report(false, "addon %s (%s %s): %v", item.Name, item.Provider, item.DataSourceName, err)
+				} else {
+					report(true, "addon %s (%s %s)", item.Name, item.Provider, item.DataSourceName)
+				}
+			}
+
+			for _, file := range sortedKeys(declarative.LoadErrors()) {
+				report(false, "language definition %s: %v", file, declarative.LoadErrors()[file])
+			}
+
+			for _, p := range plugin.Loaded() {
+				if _, err := p.Describe(cmd.Context()); err != nil {
+					report(false, "plugin %s (%s): %v", p.Name(), p.Path(), err)
+				} else {
+					report(true, "plugin %s (%s)", p.Name(), p.Path())
+				}
+			}
+			for _, file := range sortedKeys(plugin.LoadErrors()) {
+				report(false, "plugin %s: %v", file, plugin.LoadErrors()[file])
+			}
+
+			if problems > 0 {
+				return fmt.Errorf("%d problem(s) found", problems)
+			}
+			return nil
+		},
+	}
+}
+
+// printCheck 输出一项检查结果
+func printCheck(w io.Writer, ok bool, msg string) {
+	mark := color.GreenFont("✔")
+	if !ok {
+		mark = color.RedFont("✘")
+	}
+	_, _ = fmt.Fprintf(w, "%s %s\n", mark, msg)
+}
+
+// sortedKeys 返回按名称排序的加载错误的键
+func sortedKeys(errs map[string]error) []string {
+	res := make([]string, 0, len(errs))
+	for k := range errs {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/color"
+	"github.com/toodofun/gvm/internal/util/eol"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// eolRecord 单个版本线的生命周期，EOL 为空表示仍在维护且尚无截止日期
+type eolRecord struct {
+	Language string `json:"language" yaml:"language"`
+	Cycle    string `json:"cycle" yaml:"cycle"`
+	EOL      string `json:"eol" yaml:"eol"`
+	Status   string `json:"status" yaml:"status"`
+}
+
+func NewEOLCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "eol [<lang>]",
+		Short: "Show end-of-life dates of release lines",
+		Example: "  gvm eol node\n" +
+			"  gvm eol --refresh",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return fmt.Errorf("accepts at most one argument: [<lang>]")
+			}
+			return nil
+		},
+	}
+
+	var refresh bool
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "Fetch the latest end-of-life dates from upstream")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		out := cmd.OutOrStdout()
+		if err := checkOutput(); err != nil {
+			return err
+		}
+
+		langs := core.GetAllLanguage()
+		if len(args) > 0 {
+			langs = args
+		}
+
+		failed := 0
+		now := time.Now()
+		records := make([]*eolRecord, 0)
+		for _, name := range langs {
+			language, exists := core.GetLanguage(name)
+			if !exists {
+				return cmd.Help()
+			}
+			if refresh {
+				if provider, ok := language.(core.LifecycleProvider); ok {
+					cycles, err := provider.FetchLifecycles(ctx)
+					if err == nil {
+						err = eol.Save(language.Name(), cycles)
+					}
+					if err != nil {
+						_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Failed to refresh %s: %v\n", language.Name(), err)
+						failed++
+					}
+				}
+			}
+			for _, c := range eol.Load(language.Name()) {
+				r := &eolRecord{Language: language.Name(), Cycle: c.Cycle}
+				if !c.EOL.IsZero() {
+					r.EOL = c.EOL.Format(time.DateOnly)
+				}
+				if v, err := goversion.NewVersion(c.Cycle); err == nil {
+					r.Status = eol.Status([]*core.Lifecycle{c}, v, now)
+				}
+				records = append(records, r)
+			}
+		}
+
+		switch {
+		case structuredOutput():
+			if err := writeStructured(out, records); err != nil {
+				return err
+			}
+		case output == outputPlain:
+			for _, r := range records {
+				_, _ = fmt.Fprintf(out, "%s %s %s\n", r.Language, r.Cycle, r.EOL)
+			}
+		default:
+			t := newTableWriter(out)
+			t.AppendHeader(table.Row{"Language", "Cycle", "EOL", "Status"})
+			for _, r := range records {
+				date := r.EOL
+				if date == "" {
+					date = "-"
+				}
+				t.AppendRow(table.Row{r.Language, r.Cycle, date, colorEOL(r.Status)})
+			}
+			t.Render()
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("failed to refresh %d language(s)", failed)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// formatEOL 返回带颜色的生命周期提示
+func formatEOL(cycles []*core.Lifecycle, version *goversion.Version, now time.Time) string {
+	return colorEOL(eol.Status(cycles, version, now))
+}
+
+// colorEOL 已停止维护显示为红色，即将停止维护显示为黄色
+func colorEOL(status string) string {
+	switch {
+	case status == "":
+		return ""
+	case status == "EOL":
+		return color.RedFont(status)
+	default:
+		return color.YellowFont(status)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/log"
+	"github.com/toodofun/gvm/internal/util/path"
+	"github.com/toodofun/gvm/internal/util/usage"
+
+	"github.com/spf13/cobra"
+)
+
+// ExitError 命令以非零状态退出，main 以相同的状态码退出 gvm，而不是在命令中直接调用 os.Exit
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+func NewExecCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec <lang> <version> <command> [args...]",
+		Short: "Run a command with a specific version of a language",
+		Example: "  gvm exec go 1.21 go build ./...\n" +
+			"  gvm exec node 18 npm test",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 3 {
+				return fmt.Errorf("requires at least three arguments: <lang> <version> <command>")
+			}
+			return nil
+		},
+	}
+	// 命令之后的参数原样传递给命令
+	cmd.Flags().SetInterspersed(false)
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		language, exists := core.GetLanguage(args[0])
+		if !exists {
+			return cmd.Help()
+		}
+		provider, ok := language.(core.EnvProvider)
+		if !ok {
+			return fmt.Errorf("language %s does not support exec", language.Name())
+		}
+
+		spec, err := parseSpec(language, args[1])
+		if err != nil {
+			return err
+		}
+		if spec.IsSystem() {
+			for key, value := range systemEnv(provider) {
+				if len(value) == 0 {
+					err = os.Unsetenv(key)
+				} else {
+					err = os.Setenv(key, value)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			iv, err := resolveInstalled(ctx, language, spec)
+			if err != nil {
+				return err
+			}
+			for key, value := range versionEnv(language, provider, iv) {
+				if err := os.Setenv(key, value); err != nil {
+					return err
+				}
+			}
+			if err := usage.Record(language.Name(), iv.Origin, time.Now()); err != nil {
+				log.GetLogger(ctx).Warnf("Failed to record usage of %s %s: %v", language.Name(), iv.Origin, err)
+			}
+		}
+
+		c := exec.CommandContext(ctx, args[2], args[3:]...)
+		c.Stdin = os.Stdin
+		c.Stdout = cmd.OutOrStdout()
+		c.Stderr = cmd.ErrOrStderr()
+		if err := c.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return &ExitError{Code: exitErr.ExitCode()}
+			}
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// versionEnv 将语言默认版本的环境变量改为指向指定版本，追加类变量会放在当前值之前
+func versionEnv(language core.Language, provider core.EnvProvider, iv *core.InstalledVersion) map[string]string {
+	current := filepath.Join(path.GetLangRoot(language.Name()), path.Current)
+	res := make(map[string]string)
+	for _, kv := range provider.Envs() {
+		value := strings.Replace(kv.Value, current, iv.Location, 1)
+		if !kv.Append {
+			res[kv.Key] = value
+			continue
+		}
+		existing, ok := res[kv.Key]
+		if !ok {
+			existing = os.Getenv(kv.Key)
+		}
+		if len(existing) > 0 {
+			value = value + string(os.PathListSeparator) + existing
+		}
+		res[kv.Key] = value
+	}
+	return res
+}
+
+// systemEnv 移除语言默认版本设置的环境变量，使命令使用 PATH 中的系统工具链，值为空表示删除该变量
+func systemEnv(provider core.EnvProvider) map[string]string {
+	res := make(map[string]string)
+	for _, kv := range provider.Envs() {
+		if !kv.Append {
+			res[kv.Key] = ""
+			continue
+		}
+		existing, ok := res[kv.Key]
+		if !ok {
+			existing = os.Getenv(kv.Key)
+		}
+		kept := make([]string, 0)
+		for _, p := range filepath.SplitList(existing) {
+			if p != kv.Value {
+				kept = append(kept, p)
+			}
+		}
+		res[kv.Key] = strings.Join(kept, string(os.PathListSeparator))
+	}
+	return res
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/testutil"
+	"github.com/toodofun/gvm/internal/util/env"
+	"github.com/toodofun/gvm/internal/util/usage"
+)
+
+// envLanguage 在 installedLanguage 的基础上实现 core.EnvProvider，不设置任何环境变量
+type envLanguage struct {
+	installedLanguage
+}
+
+func (l *envLanguage) Envs() []env.KV {
+	return nil
+}
+
+func runExec(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	c := NewExecCmd()
+	c.SetArgs(args)
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetErr(buf)
+	err := c.Execute()
+	return buf.String(), err
+}
+
+func TestExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on windows")
+	}
+	testutil.SetRootDir(t)
+	origGetLanguage := core.GetLanguage
+	defer func() { core.GetLanguage = origGetLanguage }()
+	language := &envLanguage{installedLanguage{installed: []string{"1.21.9", "1.22.0"}}}
+	core.GetLanguage = func(name string) (core.Language, bool) {
+		return language, name == "go"
+	}
+
+	out, err := runExec(t, "go", "1.21", "sh", "-c", "echo hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", out)
+	records, err := usage.Load()
+	require.NoError(t, err)
+	_, ok := records.LastUsed("go", "1.21.9")
+	assert.True(t, ok)
+
+	// 命令的退出码通过 ExitError 返回，由 main 作为 gvm 的退出码
+	_, err = runExec(t, "go", "1.22", "sh", "-c", "exit 3")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.Code)
+}
@@ -15,11 +15,14 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/toodofun/gvm/internal/core"
 	"github.com/toodofun/gvm/internal/log"
-	"github.com/toodofun/gvm/internal/util/match"
+	"github.com/toodofun/gvm/internal/util/path"
+	"github.com/toodofun/gvm/languages"
 
 	vers "github.com/hashicorp/go-version"
 	"github.com/spf13/cobra"
@@ -37,8 +40,15 @@ func NewInstallCmd() *cobra.Command {
 		},
 	}
 
-	var setDefault bool
+	var (
+		setDefault bool
+		fromFile   string
+		fromDir    string
+	)
 	cmd.Flags().BoolVar(&setDefault, "set-default", false, "Set the installed version as default")
+	cmd.Flags().StringVar(&fromFile, "from-file", "", "Install from a local package instead of downloading it")
+	cmd.Flags().StringVar(&fromDir, "from-dir", "", "Install from an already extracted directory")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "from-dir")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		lang := args[0]
@@ -51,31 +61,25 @@ func NewInstallCmd() *cobra.Command {
 			return cmd.Help()
 		}
 
-		// 检查远程是否存在
-		versions, err := language.ListRemoteVersions(ctx)
-		if err != nil {
-			return err
-		}
-
-		vs := make([]*vers.Version, len(versions))
-		versionMap := make(map[string]*core.RemoteVersion)
-		for i, v := range versions {
-			vs[i] = v.Version
-			versionMap[v.Version.String()] = v
+		var (
+			installed string
+			err       error
+		)
+		if len(fromFile) > 0 || len(fromDir) > 0 {
+			installed, err = installLocal(ctx, language, version, fromFile, fromDir)
+		} else {
+			installed, err = installRemote(ctx, language, version)
 		}
-
-		matchedVersion, err := match.MatchVersion(version, vs)
 		if err != nil {
 			return err
 		}
-		logger.Infof("Matched version %s", versionMap[matchedVersion.String()].Version.String())
 
-		if err := language.Install(ctx, versionMap[matchedVersion.String()]); err != nil {
-			return err
+		if err := languages.RecordManifest(ctx, language, installed); err != nil {
+			logger.Warnf("%v", err)
 		}
 
 		if setDefault {
-			if err := language.SetDefaultVersion(ctx, matchedVersion.String()); err != nil {
+			if err := language.SetDefaultVersion(ctx, installed); err != nil {
 				return err
 			}
 		}
@@ -85,3 +89,68 @@ func NewInstallCmd() *cobra.Command {
 
 	return cmd
 }
+
+func installRemote(ctx context.Context, language core.Language, version string) (string, error) {
+	logger := log.GetLogger(ctx)
+
+	// 检查远程是否存在
+	rv, err := resolveRemote(ctx, language, version)
+	if err != nil {
+		return "", err
+	}
+	logger.Infof("Matched version %s", rv.Version.String())
+
+	if err := language.Install(ctx, rv); err != nil {
+		return "", err
+	}
+	return rv.Version.String(), nil
+}
+
+// installLocal 从本地安装包或目录安装，版本号必须完整给出
+func installLocal(ctx context.Context, language core.Language, version, fromFile, fromDir string) (string, error) {
+	installer, ok := language.(core.LocalInstaller)
+	if !ok {
+		return "", fmt.Errorf("language %s does not support installing from local packages", language.Name())
+	}
+	ver, err := vers.NewVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("invalid version format: %s", version)
+	}
+	source := fromFile
+	if len(fromDir) > 0 {
+		source = fromDir
+	}
+	if source, err = filepath.Abs(source); err != nil {
+		return "", err
+	}
+	if !path.IsPathExist(source) {
+		return "", fmt.Errorf("%s does not exist", source)
+	}
+
+	rv := &core.RemoteVersion{
+		Version: ver,
+		Origin:  version,
+		Comment: filepath.Base(source),
+	}
+	if len(fromFile) > 0 {
+		err = installer.InstallFromFile(ctx, rv, source)
+	} else {
+		err = installer.InstallFromDir(ctx, rv, source)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// 确认安装结果符合该语言的目录结构
+	installed, err := language.ListInstalledVersions(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, iv := range installed {
+		if iv.Version.Equal(ver) {
+			return ver.String(), nil
+		}
+	}
+	_ = language.Uninstall(ctx, ver.String())
+	return "", fmt.Errorf("%s does not contain a valid %s %s installation", source, language.Name(), version)
+}
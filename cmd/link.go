@@ -0,0 +1,85 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/toodofun/gvm/internal/core"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/spf13/cobra"
+)
+
+func NewLinkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "link <lang> <name> <path>",
+		Short: "Link an externally installed toolchain into GVM",
+		Example: "  gvm link go system /usr/local/go\n" +
+			"  gvm link java temurin17 /usr/lib/jvm/java-17-openjdk-amd64",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 3 {
+				return fmt.Errorf("requires three arguments: <lang> <name> <path>")
+			}
+			return nil
+		},
+	}
+
+	var (
+		version    string
+		setDefault bool
+	)
+	cmd.Flags().StringVar(&version, "version", "", "Version of the toolchain, detected by running it if empty")
+	cmd.Flags().BoolVar(&setDefault, "set-default", false, "Set the linked version as default")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		language, exists := core.GetLanguage(args[0])
+		if !exists {
+			return cmd.Help()
+		}
+		linker, ok := language.(core.Linker)
+		if !ok {
+			return fmt.Errorf("language %s does not support linking external toolchains", language.Name())
+		}
+
+		var ver *goversion.Version
+		if len(version) > 0 {
+			v, err := goversion.NewVersion(version)
+			if err != nil {
+				return fmt.Errorf("invalid version format: %s", version)
+			}
+			ver = v
+		}
+		target, err := filepath.Abs(args[2])
+		if err != nil {
+			return err
+		}
+
+		iv, err := linker.Link(ctx, args[1], target, ver)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Linked %s %s -> %s\n", language.Name(), iv.Version.String(), target)
+
+		if setDefault {
+			return language.SetDefaultVersion(ctx, iv.Version.String())
+		}
+		return nil
+	}
+
+	return cmd
+}
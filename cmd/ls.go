@@ -16,17 +16,20 @@ package cmd
 
 import (
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/toodofun/gvm/internal/core"
 	"github.com/toodofun/gvm/internal/util/color"
+	"github.com/toodofun/gvm/internal/util/eol"
+	"github.com/toodofun/gvm/internal/util/usage"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 )
 
 func NewLsCmd() *cobra.Command {
-	return &cobra.Command{
+	var showUsage bool
+	cmd := &cobra.Command{
 		Use:   "ls <lang>",
 		Short: "List installed versions of language",
 		Args: func(cmd *cobra.Command, args []string) error {
@@ -38,6 +41,9 @@ func NewLsCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			lang := args[0]
 			ctx := cmd.Context()
+			if err := checkOutput(); err != nil {
+				return err
+			}
 
 			language, exists := core.GetLanguage(lang)
 			if !exists {
@@ -48,55 +54,76 @@ func NewLsCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			t := table.NewWriter()
-			t.SetOutputMirror(os.Stdout)
-			t.SetStyle(table.Style{
-				Name: "custom",
-				Box: table.BoxStyle{
-					BottomLeft:       "-",
-					BottomRight:      "-",
-					BottomSeparator:  "-",
-					Left:             "",
-					LeftSeparator:    "",
-					MiddleHorizontal: "-",
-					MiddleSeparator:  "",
-					PaddingLeft:      " ",
-					PaddingRight:     " ",
-					Right:            "",
-					RightSeparator:   "",
-					TopLeft:          "-",
-					TopRight:         "-",
-					TopSeparator:     "-",
-					UnfinishedRow:    " ",
-				},
-				Options: table.Options{
-					DrawBorder:      false,
-					SeparateColumns: false,
-					SeparateHeader:  true,
-					SeparateRows:    false,
-				},
-			})
+			out := cmd.OutOrStdout()
 
 			// 获取已安装版本
 			current := language.GetDefaultVersion(ctx)
-
+			records := make([]*versionRecord, 0, len(versions))
 			for _, version := range versions {
+				records = append(records, &versionRecord{
+					Version:   version.Version.String(),
+					Origin:    version.Origin,
+					Installed: true,
+					Current:   current.Version.String() == version.Version.String(),
+					Location:  version.Location,
+				})
+			}
+
+			var usageRecords usage.Usage
+			if showUsage {
+				if usageRecords, err = usage.Load(); err != nil {
+					return err
+				}
+				for i, version := range versions {
+					if last, ok := usageRecords.LastUsed(language.Name(), version.Origin); ok {
+						records[i].LastUsed = &last
+					}
+				}
+			}
+			switch {
+			case structuredOutput():
+				return writeStructured(out, records)
+			case output == outputPlain:
+				writePlainVersions(out, records)
+				return nil
+			}
+
+			cycles := eol.Load(language.Name())
+			now := time.Now()
+
+			t := newTableWriter(out)
+			for i, version := range versions {
 				flag := ""
-				v := version.Version.String()
-				l := version.Location
-				if current.Version.Equal(version.Version) {
+				v := records[i].Version
+				l := records[i].Location
+				if records[i].Current {
 					flag = color.GreenFont("->")
 					v = color.GreenFont(v)
 					l = color.GreenFont(l)
 				}
-				t.AppendRow(table.Row{
+				row := table.Row{
 					flag,
 					v,
 					l,
-				})
+					formatEOL(cycles, version.Version, now),
+				}
+				if showUsage {
+					row = append(row, formatLastUsed(records[i].LastUsed))
+				}
+				t.AppendRow(row)
 			}
 			t.Render()
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&showUsage, "usage", false, "Show when each version was last used by gvm exec, also added as lastUsed to json and yaml output")
+	return cmd
+}
+
+// formatLastUsed 返回版本最近一次使用的时间，未使用过时返回 never
+func formatLastUsed(last *time.Time) string {
+	if last == nil {
+		return "never"
+	}
+	return last.Local().Format("2006-01-02 15:04")
 }
@@ -16,20 +16,39 @@ package cmd
 
 import (
 	"fmt"
-	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/toodofun/gvm/internal/core"
 	"github.com/toodofun/gvm/internal/util/color"
+	"github.com/toodofun/gvm/internal/util/eol"
+	"github.com/toodofun/gvm/internal/util/match"
 
 	"github.com/duke-git/lancet/v2/slice"
+	goversion "github.com/hashicorp/go-version"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 )
 
+// remoteFilter 为 ls-remote 的过滤条件，只依赖 core.RemoteVersion 的通用字段，对所有语言一致生效
+type remoteFilter struct {
+	stable     bool
+	lts        bool
+	prerelease bool
+	installed  bool
+	match      string
+	latestPer  string
+	limit      int
+}
+
 func NewLsRemoteCmd() *cobra.Command {
-	return &cobra.Command{
+	filter := &remoteFilter{}
+	cmd := &cobra.Command{
 		Use:   "ls-remote <lang>",
 		Short: "List remote versions of language",
+		Example: "  gvm ls-remote node --lts --latest-per major\n" +
+			"  gvm ls-remote go --stable --match '>=1.21 <1.23' --limit 5",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 1 {
 				return fmt.Errorf("you need to provide language information, such as golang or node")
@@ -39,79 +58,165 @@ func NewLsRemoteCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			lang := args[0]
 			ctx := cmd.Context()
+			if err := checkOutput(); err != nil {
+				return err
+			}
 
 			language, exists := core.GetLanguage(lang)
 			if !exists {
 				return cmd.Help()
 			}
-			if versions, err := language.ListRemoteVersions(ctx); err != nil {
+			versions, err := language.ListRemoteVersions(ctx)
+			if err != nil {
 				return err
-			} else {
-				t := table.NewWriter()
-				t.SetOutputMirror(os.Stdout)
-				t.SetStyle(table.Style{
-					Name: "custom",
-					Box: table.BoxStyle{
-						BottomLeft:       "-",
-						BottomRight:      "-",
-						BottomSeparator:  "-",
-						Left:             "",
-						LeftSeparator:    "",
-						MiddleHorizontal: "-",
-						MiddleSeparator:  "",
-						PaddingLeft:      " ",
-						PaddingRight:     " ",
-						Right:            "",
-						RightSeparator:   "",
-						TopLeft:          "-",
-						TopRight:         "-",
-						TopSeparator:     "-",
-						UnfinishedRow:    " ",
-					},
-					Options: table.Options{
-						DrawBorder:      false,
-						SeparateColumns: false,
-						SeparateHeader:  true,
-						SeparateRows:    false,
-					},
-				})
+			}
+			out := cmd.OutOrStdout()
 
-				// 获取已安装列表
-				installedVersions, err := language.ListInstalledVersions(ctx)
-				if err != nil {
-					installedVersions = make([]*core.InstalledVersion, 0)
-				}
+			// 获取已安装列表
+			installedVersions, err := language.ListInstalledVersions(ctx)
+			if err != nil {
+				installedVersions = make([]*core.InstalledVersion, 0)
+			}
+			installedLocations := make(map[string]string)
+			for _, iv := range installedVersions {
+				installedLocations[iv.Version.String()] = iv.Location
+			}
+			if versions, err = filter.apply(versions, installedLocations); err != nil {
+				return err
+			}
 
-				installedVersionList := make([]string, 0)
-				for _, iv := range installedVersions {
-					installedVersionList = append(installedVersionList, iv.Version.String())
-				}
+			// 获取已安装版本
+			current := language.GetDefaultVersion(ctx)
+			records := make([]*versionRecord, 0, len(versions))
+			for _, version := range versions {
+				location, installed := installedLocations[version.Version.String()]
+				records = append(records, &versionRecord{
+					Version:   version.Version.String(),
+					Origin:    version.Origin,
+					Comment:   version.Comment,
+					Installed: installed,
+					Current:   current.Version.String() == version.Version.String(),
+					Location:  location,
+				})
+			}
+			switch {
+			case structuredOutput():
+				return writeStructured(out, records)
+			case output == outputPlain:
+				writePlainVersions(out, records)
+				return nil
+			}
 
-				// 获取已安装版本
-				current := language.GetDefaultVersion(ctx)
-
-				for _, version := range versions {
-					v := version.Version.String()
-					c := version.Comment
-					flag := ""
-					if current.Version.Equal(version.Version) {
-						flag = color.GreenFont("->")
-					}
-					if slice.Contain(installedVersionList, v) {
-						v = color.GreenFont(fmt.Sprintf("%s(installed)", v))
-						c = color.GreenFont(c)
-					}
-					t.AppendRow(table.Row{
-						flag,
-						v,
-						c,
-					})
+			cycles := eol.Load(language.Name())
+			now := time.Now()
+			t := newTableWriter(out)
+			for i, version := range versions {
+				v := records[i].Version
+				c := records[i].Comment
+				flag := ""
+				if records[i].Current {
+					flag = color.GreenFont("->")
 				}
-
-				t.Render()
+				if records[i].Installed {
+					v = color.GreenFont(fmt.Sprintf("%s(installed)", v))
+					c = color.GreenFont(c)
+				}
+				t.AppendRow(table.Row{
+					flag,
+					v,
+					c,
+					formatEOL(cycles, version.Version, now),
+				})
 			}
+			t.Render()
 
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&filter.stable, "stable", false, "Only show stable releases")
+	cmd.Flags().BoolVar(&filter.lts, "lts", false, "Only show long-term support releases")
+	cmd.Flags().BoolVar(&filter.prerelease, "prerelease", false, "Only show pre-releases")
+	cmd.Flags().BoolVar(&filter.installed, "installed", false, "Only show installed versions")
+	cmd.Flags().StringVar(&filter.match, "match", "", "Only show versions matching a constraint, such as '>=18 <21'")
+	cmd.Flags().StringVar(&filter.latestPer, "latest-per", "", "Only show the latest version of each minor or major line")
+	cmd.Flags().IntVar(&filter.limit, "limit", 0, "Only show the newest N versions")
+	return cmd
+}
+
+// apply 依次按类型、安装状态和版本约束过滤，再按版本线取最新版本并限制数量，结果保持原有顺序
+func (f *remoteFilter) apply(versions []*core.RemoteVersion, installed map[string]string) ([]*core.RemoteVersion, error) {
+	if f.stable && f.prerelease {
+		return nil, fmt.Errorf("--stable can not be used together with --prerelease")
+	}
+	if f.latestPer != "" && f.latestPer != "minor" && f.latestPer != "major" {
+		return nil, fmt.Errorf("unsupported --latest-per value %s, supported: minor, major", f.latestPer)
+	}
+	if f.limit < 0 {
+		return nil, fmt.Errorf("--limit must not be negative")
+	}
+	var constraints goversion.Constraints
+	if f.match != "" {
+		c, err := match.ParseConstraint(f.match)
+		if err != nil {
+			return nil, err
+		}
+		constraints = c
+	}
+
+	res := make([]*core.RemoteVersion, 0, len(versions))
+	for _, rv := range versions {
+		pre := match.IsPrerelease(rv.Version) || strings.EqualFold(rv.Comment, "Prerelease")
+		if (f.stable && pre) || (f.prerelease && !pre) {
+			continue
+		}
+		if f.lts && !strings.Contains(strings.ToUpper(rv.Comment), "LTS") {
+			continue
+		}
+		if _, ok := installed[rv.Version.String()]; f.installed && !ok {
+			continue
+		}
+		if constraints != nil && !match.Check(constraints, rv.Version) {
+			continue
+		}
+		res = append(res, rv)
+	}
+
+	if f.latestPer != "" {
+		latest := make(map[string]*core.RemoteVersion)
+		for _, rv := range res {
+			key := lineKey(rv.Version, f.latestPer)
+			if cur, ok := latest[key]; !ok || rv.Version.GreaterThan(cur.Version) {
+				latest[key] = rv
+			}
+		}
+		res = slice.Filter(res, func(_ int, rv *core.RemoteVersion) bool {
+			return latest[lineKey(rv.Version, f.latestPer)] == rv
+		})
+	}
+
+	if f.limit > 0 && len(res) > f.limit {
+		newest := make([]*core.RemoteVersion, len(res))
+		copy(newest, res)
+		sort.SliceStable(newest, func(i, j int) bool {
+			return newest[i].Version.GreaterThan(newest[j].Version)
+		})
+		keep := make(map[*core.RemoteVersion]bool, f.limit)
+		for _, rv := range newest[:f.limit] {
+			keep[rv] = true
+		}
+		res = slice.Filter(res, func(_ int, rv *core.RemoteVersion) bool {
+			return keep[rv]
+		})
+	}
+	return res, nil
+}
+
+// lineKey 返回版本所在的主版本线或次版本线
+func lineKey(v *goversion.Version, per string) string {
+	s := v.Segments()
+	if per == "major" {
+		return fmt.Sprintf("%d", s[0])
+	}
+	return fmt.Sprintf("%d.%d", s[0], s[1])
 }
@@ -0,0 +1,107 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+func remoteVersions(comments map[string]string, versions ...string) []*core.RemoteVersion {
+	res := make([]*core.RemoteVersion, 0, len(versions))
+	for _, v := range versions {
+		res = append(res, &core.RemoteVersion{
+			Version: goversion.Must(goversion.NewVersion(v)),
+			Origin:  v,
+			Comment: comments[v],
+		})
+	}
+	return res
+}
+
+func filteredVersions(t *testing.T, f *remoteFilter, versions []*core.RemoteVersion, installed map[string]string) []string {
+	t.Helper()
+	res, err := f.apply(versions, installed)
+	require.NoError(t, err)
+	out := make([]string, 0, len(res))
+	for _, rv := range res {
+		out = append(out, rv.Version.String())
+	}
+	return out
+}
+
+func TestRemoteFilter_Apply(t *testing.T) {
+	comments := map[string]string{
+		"18.19.0": "LTS: Hydrogen",
+		"18.20.1": "LTS: Hydrogen",
+		"20.11.0": "LTS: Iron",
+	}
+	versions := remoteVersions(comments,
+		"17.9.1", "18.19.0", "18.20.1", "19.9.0", "20.11.0", "21.0.0-rc.1", "21.0.0", "21.1.0")
+	installed := map[string]string{"18.19.0": "/gvm/node/v18.19.0"}
+
+	tests := []struct {
+		name   string
+		filter *remoteFilter
+		want   []string
+	}{
+		{name: "no filter", filter: &remoteFilter{}, want: []string{
+			"17.9.1", "18.19.0", "18.20.1", "19.9.0", "20.11.0", "21.0.0-rc.1", "21.0.0", "21.1.0"}},
+		{name: "stable", filter: &remoteFilter{stable: true}, want: []string{
+			"17.9.1", "18.19.0", "18.20.1", "19.9.0", "20.11.0", "21.0.0", "21.1.0"}},
+		{name: "prerelease", filter: &remoteFilter{prerelease: true}, want: []string{"21.0.0-rc.1"}},
+		{name: "lts", filter: &remoteFilter{lts: true}, want: []string{"18.19.0", "18.20.1", "20.11.0"}},
+		{name: "installed", filter: &remoteFilter{installed: true}, want: []string{"18.19.0"}},
+		{name: "match", filter: &remoteFilter{match: ">=18 <21"}, want: []string{
+			"18.19.0", "18.20.1", "19.9.0", "20.11.0"}},
+		{name: "latest per major", filter: &remoteFilter{stable: true, latestPer: "major"}, want: []string{
+			"17.9.1", "18.20.1", "19.9.0", "20.11.0", "21.1.0"}},
+		{name: "limit keeps newest", filter: &remoteFilter{limit: 2}, want: []string{"21.0.0", "21.1.0"}},
+		{name: "combined", filter: &remoteFilter{lts: true, latestPer: "major", limit: 1}, want: []string{"20.11.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, filteredVersions(t, tt.filter, versions, installed))
+		})
+	}
+}
+
+func TestRemoteFilter_ApplyJava(t *testing.T) {
+	// Java 的发行版名称在预发布位置，约束只比较版本号
+	versions := remoteVersions(nil, "11.0.21-zulu-ab12", "17.0.9-zulu-ab12", "21.0.1-tem", "22.0.0-tem")
+	assert.Equal(t, []string{"17.0.9-zulu-ab12", "21.0.1-tem"},
+		filteredVersions(t, &remoteFilter{match: ">=17 <22"}, versions, nil))
+	assert.Equal(t, []string{"11.0.21-zulu-ab12", "17.0.9-zulu-ab12", "21.0.1-tem", "22.0.0-tem"},
+		filteredVersions(t, &remoteFilter{stable: true}, versions, nil))
+}
+
+func TestRemoteFilter_ApplyInvalid(t *testing.T) {
+	versions := remoteVersions(nil, "1.22.0")
+	for _, f := range []*remoteFilter{
+		{stable: true, prerelease: true},
+		{latestPer: "patch"},
+		{limit: -1},
+		{match: ">="},
+	} {
+		_, err := f.apply(versions, nil)
+		assert.Error(t, err)
+	}
+}
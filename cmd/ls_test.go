@@ -0,0 +1,71 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/testutil"
+	"github.com/toodofun/gvm/internal/util/usage"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// defaultLanguage 在 installedLanguage 的基础上提供默认版本
+type defaultLanguage struct {
+	installedLanguage
+}
+
+func (l *defaultLanguage) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
+	return &core.InstalledVersion{Version: goversion.Must(goversion.NewVersion("0.0.0"))}
+}
+
+func TestLsUsageStructured(t *testing.T) {
+	testutil.SetRootDir(t)
+	defer func() { output = outputTable }()
+	origGetLanguage := core.GetLanguage
+	defer func() { core.GetLanguage = origGetLanguage }()
+	language := &defaultLanguage{installedLanguage{installed: []string{"1.21.9", "1.22.0"}}}
+	core.GetLanguage = func(name string) (core.Language, bool) {
+		return language, name == "go"
+	}
+
+	last := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	require.NoError(t, usage.Record("go", "1.22.0", last))
+
+	output = outputJSON
+	c := NewLsCmd()
+	c.SetArgs([]string{"go", "--usage"})
+	buf := new(bytes.Buffer)
+	c.SetOut(buf)
+	c.SetErr(buf)
+	require.NoError(t, c.Execute())
+
+	var records []*versionRecord
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &records))
+	require.Len(t, records, 2)
+	assert.Nil(t, records[0].LastUsed)
+	if assert.NotNil(t, records[1].LastUsed) {
+		assert.True(t, last.Equal(*records[1].LastUsed))
+	}
+}
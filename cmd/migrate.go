@@ -0,0 +1,254 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/migrate"
+	"github.com/toodofun/gvm/internal/util/match"
+	"github.com/toodofun/gvm/internal/util/path"
+	"github.com/toodofun/gvm/languages"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/spf13/cobra"
+)
+
+func NewMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate <tool>",
+		Short: "Import versions installed by another version manager",
+		Long: "Import versions installed by another version manager.\n" +
+			"Supported tools: " + strings.Join(migrate.GetAllTools(), ", "),
+		Example: "  gvm migrate nvm\n" +
+			"  gvm migrate pyenv --move",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("requires exactly one argument: <tool>")
+			}
+			return nil
+		},
+	}
+
+	var (
+		move bool
+		dir  string
+	)
+	cmd.Flags().BoolVar(&move, "move", false, "Move the versions into GVM instead of linking them")
+	cmd.Flags().StringVar(&dir, "dir", "", "Root directory of the tool, detected from its environment if empty")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		out := cmd.OutOrStdout()
+
+		tool, ok := migrate.GetTool(args[0])
+		if !ok {
+			return fmt.Errorf("unsupported tool %s, supported: %s", args[0], strings.Join(migrate.GetAllTools(), ", "))
+		}
+		language, exists := core.GetLanguage(tool.Lang)
+		if !exists {
+			return fmt.Errorf("%s versions managed by %s can not be imported, gvm does not support %s yet",
+				tool.Lang, tool.Name, tool.Lang)
+		}
+		linker, ok := language.(core.Linker)
+		if !ok {
+			return fmt.Errorf("language %s does not support linking external toolchains", language.Name())
+		}
+
+		root := dir
+		if len(root) == 0 {
+			r, err := tool.Root()
+			if err != nil {
+				return err
+			}
+			root = r
+		}
+		if !path.IsDir(root) {
+			return fmt.Errorf("%s not found at %s", tool.Name, root)
+		}
+
+		installs, skipped, err := tool.Scan(root)
+		if err != nil {
+			return err
+		}
+		for _, s := range skipped {
+			_, _ = fmt.Fprintf(out, "Skipped %s: unrecognized version directory\n", s)
+		}
+
+		installed, err := language.ListInstalledVersions(ctx)
+		if err != nil {
+			return err
+		}
+		existing := make(map[string]bool)
+		for _, iv := range installed {
+			existing[iv.Version.String()] = true
+		}
+
+		// imported 记录工具中的目录名到 gvm 版本的映射，用于迁移默认版本
+		imported := make(map[string]*goversion.Version)
+		for _, inst := range installs {
+			v, err := importVersion(ctx, out, language, linker, inst, existing, move)
+			if err != nil {
+				_, _ = fmt.Fprintf(out, "Failed to import %s: %v\n", inst.Dir, err)
+				continue
+			}
+			imported[inst.Dir] = v
+		}
+
+		if err := migrateDefault(ctx, out, language, tool, root, imported); err != nil {
+			return err
+		}
+
+		if home, err := os.UserHomeDir(); err == nil {
+			printRcLines(out, tool, home)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// importVersion 以软链接或移动的方式导入一个版本，已存在的版本直接复用
+func importVersion(
+	ctx context.Context,
+	out io.Writer,
+	language core.Language,
+	linker core.Linker,
+	inst *migrate.Install,
+	existing map[string]bool,
+	move bool,
+) (*goversion.Version, error) {
+	want := inst.Version.String()
+	if len(inst.Name) > 0 {
+		want = fmt.Sprintf("%s+%s", strings.SplitN(want, "+", 2)[0], inst.Name)
+	}
+	if existing[want] {
+		_, _ = fmt.Fprintf(out, "%s %s already present, skipped\n", language.Name(), want)
+		return goversion.NewVersion(want)
+	}
+
+	iv, err := linker.Link(ctx, inst.Name, inst.Path, inst.Version)
+	if err != nil {
+		return nil, err
+	}
+	if !move {
+		_, _ = fmt.Fprintf(out, "Linked %s %s -> %s\n", language.Name(), iv.Version.String(), inst.Path)
+		return iv.Version, nil
+	}
+
+	if err := materialize(iv.Location, inst.Path); err != nil {
+		_, _ = fmt.Fprintf(out, "Linked %s %s -> %s (move failed: %v)\n",
+			language.Name(), iv.Version.String(), inst.Path, err)
+		return iv.Version, nil
+	}
+	if err := languages.RecordManifest(ctx, language, iv.Version.String()); err != nil {
+		return nil, err
+	}
+	_, _ = fmt.Fprintf(out, "Moved %s %s from %s\n", language.Name(), iv.Version.String(), inst.Path)
+	return iv.Version, nil
+}
+
+// materialize 将 Link 创建的软链接替换为目标目录本身，失败时恢复软链接
+func materialize(location, target string) error {
+	link := location
+	if info, err := os.Lstat(location); err != nil {
+		return err
+	} else if info.Mode()&os.ModeSymlink == 0 {
+		entries, err := os.ReadDir(location)
+		if err != nil {
+			return err
+		}
+		link = ""
+		for _, entry := range entries {
+			p := filepath.Join(location, entry.Name())
+			if dest, err := os.Readlink(p); err == nil && dest == target {
+				link = p
+				break
+			}
+		}
+		if len(link) == 0 {
+			return fmt.Errorf("no link to %s found in %s", target, location)
+		}
+	}
+
+	if err := os.Remove(link); err != nil {
+		return err
+	}
+	if err := os.Rename(target, link); err != nil {
+		_ = os.Symlink(target, link)
+		return err
+	}
+	return nil
+}
+
+// migrateDefault 将工具的全局默认版本设置为 gvm 的默认版本
+func migrateDefault(
+	ctx context.Context,
+	out io.Writer,
+	language core.Language,
+	tool *migrate.Tool,
+	root string,
+	imported map[string]*goversion.Version,
+) error {
+	d := tool.DefaultVersion(root)
+	if len(d) == 0 || d == "system" {
+		return nil
+	}
+
+	target, ok := imported[d]
+	if !ok {
+		versions := make([]*goversion.Version, 0, len(imported))
+		for _, v := range imported {
+			versions = append(versions, v)
+		}
+		query := strings.TrimPrefix(d, "v")
+		if d == "node" || d == "stable" {
+			query = "latest"
+		}
+		if len(versions) > 0 {
+			if v, err := match.MatchVersion(query, versions); err == nil {
+				target = v
+			}
+		}
+	}
+	if target == nil {
+		_, _ = fmt.Fprintf(out, "Default version %s of %s could not be matched, run `gvm use %s <version>` manually\n",
+			d, tool.Name, language.Name())
+		return nil
+	}
+
+	if err := language.SetDefaultVersion(ctx, target.String()); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "Default %s version set to %s\n", language.Name(), target.String())
+	return nil
+}
+
+func printRcLines(out io.Writer, tool *migrate.Tool, home string) {
+	lines := tool.ScanRcFiles(home)
+	if len(lines) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(out, "\nThe following lines belong to %s and can be removed once you switch to gvm:\n", tool.Name)
+	for _, l := range lines {
+		_, _ = fmt.Fprintf(out, "  %s:%d: %s\n", l.File, l.Line, l.Text)
+	}
+}
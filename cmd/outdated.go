@@ -0,0 +1,232 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/color"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failOnPatch   = "patch"
+	failOnMinor   = "minor"
+	failOnMajor   = "major"
+	failOnMissing = "missing"
+)
+
+// outdatedEntry 单个已安装版本的检查结果，Patch/Minor/Major 为对应级别的最新正式版本
+type outdatedEntry struct {
+	Language string `json:"language" yaml:"language"`
+	Version  string `json:"version" yaml:"version"`
+	Current  bool   `json:"current" yaml:"current"`
+	Patch    string `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Minor    string `json:"minor,omitempty" yaml:"minor,omitempty"`
+	Major    string `json:"major,omitempty" yaml:"major,omitempty"`
+	Upstream bool   `json:"upstream" yaml:"upstream"`
+}
+
+type outdatedError struct {
+	Language string `json:"language" yaml:"language"`
+	Error    string `json:"error" yaml:"error"`
+}
+
+type outdatedReport struct {
+	Versions []*outdatedEntry `json:"versions" yaml:"versions"`
+	Errors   []*outdatedError `json:"errors" yaml:"errors"`
+}
+
+func NewOutdatedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "outdated [<lang>]",
+		Short: "Report installed versions that have newer releases",
+		Example: "  gvm outdated\n" +
+			"  gvm outdated go --output json --fail-on patch,missing",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return fmt.Errorf("accepts at most one argument: [<lang>]")
+			}
+			return nil
+		},
+	}
+
+	var failOn []string
+	cmd.Flags().StringSliceVar(&failOn, "fail-on", nil,
+		"Exit with an error when a version has a newer patch, minor or major release, or is missing upstream")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if err := checkOutput(); err != nil {
+			return err
+		}
+		for _, f := range failOn {
+			switch f {
+			case failOnPatch, failOnMinor, failOnMajor, failOnMissing:
+			default:
+				return fmt.Errorf("unsupported --fail-on value %s, supported: patch, minor, major, missing", f)
+			}
+		}
+
+		langs := core.GetAllLanguage()
+		if len(args) > 0 {
+			langs = args
+		}
+
+		report := &outdatedReport{
+			Versions: make([]*outdatedEntry, 0),
+			Errors:   make([]*outdatedError, 0),
+		}
+		for _, name := range langs {
+			language, exists := core.GetLanguage(name)
+			if !exists {
+				return cmd.Help()
+			}
+			installed, err := language.ListInstalledVersions(ctx)
+			if err != nil {
+				report.Errors = append(report.Errors, &outdatedError{Language: language.Name(), Error: err.Error()})
+				continue
+			}
+			if len(installed) == 0 {
+				continue
+			}
+			remoteVersions, err := language.ListRemoteVersions(ctx)
+			if err != nil {
+				report.Errors = append(report.Errors, &outdatedError{Language: language.Name(), Error: err.Error()})
+				continue
+			}
+			current := filepath.Base(language.GetDefaultVersion(ctx).Location)
+			for _, iv := range installed {
+				entry := checkOutdated(iv, remoteVersions)
+				entry.Language = language.Name()
+				entry.Current = iv.Origin == current
+				report.Versions = append(report.Versions, entry)
+			}
+		}
+
+		out := cmd.OutOrStdout()
+		switch {
+		case structuredOutput():
+			if err := writeStructured(out, report); err != nil {
+				return err
+			}
+		case output == outputPlain:
+			for _, e := range report.Versions {
+				if e.Patch != "" || e.Minor != "" || e.Major != "" || !e.Upstream {
+					_, _ = fmt.Fprintf(out, "%s %s\n", e.Language, e.Version)
+				}
+			}
+		default:
+			renderOutdated(out, report)
+		}
+
+		if len(report.Errors) > 0 {
+			return fmt.Errorf("failed to check %d language(s)", len(report.Errors))
+		}
+		if n := countFailures(report.Versions, failOn); n > 0 {
+			return fmt.Errorf("%d version(s) matched --fail-on %s", n, strings.Join(failOn, ","))
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// checkOutdated 将已安装版本与远程版本比较，只考虑正式版本，构建元数据（如 gvm link 的名称）会被忽略
+func checkOutdated(iv *core.InstalledVersion, remoteVersions []*core.RemoteVersion) *outdatedEntry {
+	entry := &outdatedEntry{Version: iv.Version.String()}
+	cur := iv.Version.Core()
+	segments := cur.Segments()
+
+	var patch, minor, major *core.RemoteVersion
+	newer := func(rv, than *core.RemoteVersion) bool {
+		return than == nil || rv.Version.Core().GreaterThan(than.Version.Core())
+	}
+	for _, rv := range remoteVersions {
+		if rv.Version.Core().Equal(cur) {
+			entry.Upstream = true
+			break
+		}
+	}
+	for _, rv := range newerReleases(iv.Version, remoteVersions) {
+		s := rv.Version.Segments()
+		switch {
+		case s[0] != segments[0]:
+			if newer(rv, major) {
+				major = rv
+			}
+		case s[1] != segments[1]:
+			if newer(rv, minor) {
+				minor = rv
+			}
+		default:
+			if newer(rv, patch) {
+				patch = rv
+			}
+		}
+	}
+	if patch != nil {
+		entry.Patch = patch.Version.String()
+	}
+	if minor != nil {
+		entry.Minor = minor.Version.String()
+	}
+	if major != nil {
+		entry.Major = major.Version.String()
+	}
+	return entry
+}
+
+func countFailures(entries []*outdatedEntry, failOn []string) int {
+	n := 0
+	for _, e := range entries {
+		for _, f := range failOn {
+			if (f == failOnPatch && e.Patch != "") ||
+				(f == failOnMinor && e.Minor != "") ||
+				(f == failOnMajor && e.Major != "") ||
+				(f == failOnMissing && !e.Upstream) {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+func renderOutdated(out io.Writer, report *outdatedReport) {
+	t := newTableWriter(out)
+	t.AppendHeader(table.Row{"", "Language", "Version", "Patch", "Minor", "Major", "Upstream"})
+	for _, e := range report.Versions {
+		flag := ""
+		if e.Current {
+			flag = color.GreenFont("->")
+		}
+		upstream := "listed"
+		if !e.Upstream {
+			upstream = color.RedFont("missing")
+		}
+		t.AppendRow(table.Row{flag, e.Language, e.Version, e.Patch, e.Minor, e.Major, upstream})
+	}
+	t.Render()
+	for _, e := range report.Errors {
+		_, _ = fmt.Fprintf(out, "%s: %s\n", e.Language, color.RedFont(e.Error))
+	}
+}
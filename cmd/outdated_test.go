@@ -0,0 +1,71 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/toodofun/gvm/internal/core"
+)
+
+func TestCheckOutdated(t *testing.T) {
+	remote := make([]*core.RemoteVersion, 0)
+	for _, v := range []string{"1.20.1", "1.20.5", "1.21.3", "1.22.0-rc1", "2.0.0", "2.1.0"} {
+		remote = append(remote, &core.RemoteVersion{Version: goversion.Must(goversion.NewVersion(v))})
+	}
+
+	tests := []struct {
+		version string
+		want    outdatedEntry
+	}{
+		{version: "1.20.1", want: outdatedEntry{Version: "1.20.1", Patch: "1.20.5", Minor: "1.21.3", Major: "2.1.0", Upstream: true}},
+		{version: "1.21.3", want: outdatedEntry{Version: "1.21.3", Major: "2.1.0", Upstream: true}},
+		{version: "1.20.1+system", want: outdatedEntry{Version: "1.20.1+system", Patch: "1.20.5", Minor: "1.21.3", Major: "2.1.0", Upstream: true}},
+		{version: "2.1.0", want: outdatedEntry{Version: "2.1.0", Upstream: true}},
+		{version: "1.19.9", want: outdatedEntry{Version: "1.19.9", Minor: "1.21.3", Major: "2.1.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			iv := &core.InstalledVersion{Version: goversion.Must(goversion.NewVersion(tt.version)), Origin: tt.version}
+			assert.Equal(t, tt.want, *checkOutdated(iv, remote))
+		})
+	}
+
+	// Java 版本带有发行版后缀，不能被当作预发布版本跳过
+	javaRemote := make([]*core.RemoteVersion, 0)
+	for _, v := range []string{"17.0.9-zulu-ab12", "17.0.13-zulu-cd34", "21.0.5-zulu-ef56"} {
+		javaRemote = append(javaRemote, &core.RemoteVersion{Version: goversion.Must(goversion.NewVersion(v))})
+	}
+	t.Run("java", func(t *testing.T) {
+		iv := &core.InstalledVersion{Version: goversion.Must(goversion.NewVersion("17.0.9-zulu-ab12"))}
+		want := outdatedEntry{Version: "17.0.9-zulu-ab12", Patch: "17.0.13-zulu-cd34", Major: "21.0.5-zulu-ef56", Upstream: true}
+		assert.Equal(t, want, *checkOutdated(iv, javaRemote))
+	})
+}
+
+func TestCountFailures(t *testing.T) {
+	entries := []*outdatedEntry{
+		{Version: "1.20.1", Patch: "1.20.5", Upstream: true},
+		{Version: "1.21.3", Major: "2.1.0", Upstream: true},
+		{Version: "1.19.9", Minor: "1.21.3"},
+	}
+	assert.Equal(t, 0, countFailures(entries, nil))
+	assert.Equal(t, 1, countFailures(entries, []string{failOnPatch}))
+	assert.Equal(t, 2, countFailures(entries, []string{failOnPatch, failOnMissing}))
+	assert.Equal(t, 3, countFailures(entries, []string{failOnPatch, failOnMinor, failOnMajor}))
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputPlain = "plain"
+)
+
+// versionRecord 为 ls、ls-remote 和 current 结构化输出的版本信息，字段属于对外约定，只增不改
+type versionRecord struct {
+	Version   string `json:"version" yaml:"version"`
+	Origin    string `json:"origin" yaml:"origin"`
+	Comment   string `json:"comment" yaml:"comment"`
+	Installed bool   `json:"installed" yaml:"installed"`
+	Current   bool   `json:"current" yaml:"current"`
+	Location  string `json:"location" yaml:"location"`
+	// LastUsed 仅在 gvm ls --usage 时输出，从未使用过的版本为空
+	LastUsed *time.Time `json:"lastUsed,omitempty" yaml:"lastUsed,omitempty"`
+}
+
+// checkOutput 校验全局参数 --output
+func checkOutput() error {
+	switch output {
+	case outputTable, outputJSON, outputYAML, outputPlain:
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %s, supported: table, json, yaml, plain", output)
+	}
+}
+
+// structuredOutput 返回当前输出格式是否为 json 或 yaml
+func structuredOutput() bool {
+	return output == outputJSON || output == outputYAML
+}
+
+// writeStructured 按 --output 指定的 json 或 yaml 格式输出 v
+func writeStructured(w io.Writer, v any) error {
+	if output == outputYAML {
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writePlainVersions 每行输出一个版本号，便于脚本直接读取
+func writePlainVersions(w io.Writer, records []*versionRecord) {
+	for _, r := range records {
+		_, _ = fmt.Fprintln(w, r.Version)
+	}
+}
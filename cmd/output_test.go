@@ -0,0 +1,59 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteStructured(t *testing.T) {
+	defer func() { output = outputTable }()
+	records := []*versionRecord{{
+		Version:   "1.22.0",
+		Origin:    "go1.22.0",
+		Installed: true,
+		Current:   true,
+		Location:  "/root/.gvm/go/1.22.0",
+	}}
+
+	output = outputJSON
+	var buf bytes.Buffer
+	require.NoError(t, writeStructured(&buf, records))
+	assert.JSONEq(t, `[{"version":"1.22.0","origin":"go1.22.0","comment":"","installed":true,"current":true,"location":"/root/.gvm/go/1.22.0"}]`, buf.String())
+
+	output = outputYAML
+	buf.Reset()
+	require.NoError(t, writeStructured(&buf, records))
+	assert.YAMLEq(t, "- version: 1.22.0\n  origin: go1.22.0\n  comment: \"\"\n  installed: true\n  current: true\n  location: /root/.gvm/go/1.22.0\n", buf.String())
+
+	output = outputPlain
+	buf.Reset()
+	writePlainVersions(&buf, records)
+	assert.Equal(t, "1.22.0\n", buf.String())
+}
+
+func TestCheckOutput(t *testing.T) {
+	defer func() { output = outputTable }()
+	for _, o := range []string{outputTable, outputJSON, outputYAML, outputPlain} {
+		output = o
+		assert.NoError(t, checkOutput())
+	}
+	output = "xml"
+	assert.Error(t, checkOutput())
+}
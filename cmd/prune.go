@@ -0,0 +1,286 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/log"
+	"github.com/toodofun/gvm/internal/project"
+	"github.com/toodofun/gvm/internal/util/file"
+	"github.com/toodofun/gvm/internal/util/match"
+	"github.com/toodofun/gvm/internal/util/usage"
+
+	"github.com/duke-git/lancet/v2/formatter"
+	"github.com/spf13/cobra"
+)
+
+// pruneOptions 为 prune 的保留策略，满足任一策略的版本都会被保留
+type pruneOptions struct {
+	keep            int
+	keepLatestPatch bool
+	// pinned 为项目文件中固定的版本，comments 为远程版本的说明，用于解析 lts/hydrogen 这类说明符
+	pinned   []project.Pin
+	comments map[string]string
+	// unusedFor 大于 0 时保留在该时间内使用过的版本，lastUsed 的 key 为版本目录名
+	unusedFor time.Duration
+	lastUsed  map[string]time.Time
+	now       time.Time
+}
+
+func NewPruneCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune [<lang>]",
+		Short: "Remove installed versions that are not kept by any policy",
+		Long: "Remove installed versions that are not kept by any policy.\n" +
+			"A version is kept when any given policy keeps it, the current default is always kept.\n" +
+			"With --keep-pinned, pins that can not be parsed or match no installed version are skipped with a warning;\n" +
+			"nothing is removed if a pin such as lts/* can not be checked because remote versions are unavailable.",
+		Example: "  gvm prune go --keep-latest-patch --dry-run\n" +
+			"  gvm prune --keep 2 --keep-pinned ~/src",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return fmt.Errorf("accepts at most one argument: [<lang>]")
+			}
+			return nil
+		},
+	}
+
+	var (
+		opts       pruneOptions
+		pinnedDirs []string
+		unusedFor  string
+		dryRun     bool
+	)
+	cmd.Flags().IntVar(&opts.keep, "keep", -1, "Keep the N newest versions")
+	cmd.Flags().BoolVar(&opts.keepLatestPatch, "keep-latest-patch", false, "Keep the latest patch release of every minor version")
+	cmd.Flags().StringSliceVar(&pinnedDirs, "keep-pinned", nil,
+		"Keep versions pinned by project files under these directories, pins that match no installed version are skipped")
+	cmd.Flags().StringVar(&unusedFor, "unused-for", "", "Keep versions used within this period, such as 90d, 4w or 720h")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without removing anything")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		out := cmd.OutOrStdout()
+		if len(unusedFor) > 0 {
+			d, err := parsePeriod(unusedFor)
+			if err != nil {
+				return err
+			}
+			opts.unusedFor = d
+		}
+		// --keep 0 不保留任何版本，会删除除当前版本外的所有版本，不作为策略接受
+		if cmd.Flags().Changed("keep") && opts.keep < 1 {
+			return fmt.Errorf("--keep must be at least 1, got %d", opts.keep)
+		}
+		if opts.keep < 0 && !opts.keepLatestPatch && len(pinnedDirs) == 0 && opts.unusedFor == 0 {
+			return fmt.Errorf("at least one policy is required: --keep, --keep-latest-patch, --keep-pinned or --unused-for")
+		}
+		records, err := usage.Load()
+		if err != nil {
+			return err
+		}
+		opts.now = time.Now()
+
+		langs := core.GetAllLanguage()
+		if len(args) > 0 {
+			langs = []string{args[0]}
+		}
+
+		pins, err := project.Scan(pinnedDirs)
+		if err != nil {
+			return err
+		}
+
+		var reclaimed int64
+		for _, name := range langs {
+			language, exists := core.GetLanguage(name)
+			if !exists {
+				return cmd.Help()
+			}
+			installed, err := language.ListInstalledVersions(ctx)
+			if err != nil {
+				return err
+			}
+
+			o := opts
+			o.pinned = nil
+			for _, p := range pins {
+				if p.Lang != language.Name() {
+					continue
+				}
+				// 无法解析的固定版本（如 pyenv 的虚拟环境名）不会对应已安装的版本，跳过即可
+				spec, err := parseSpec(language, p.Version)
+				if err != nil {
+					log.GetLogger(ctx).Warnf("Skip %s version pinned in %s: %v", language.Name(), p.File, err)
+					continue
+				}
+				// 别名在这里展开为指向的版本
+				o.pinned = append(o.pinned, project.Pin{Lang: p.Lang, Version: spec.String(), File: p.File})
+				if spec.NeedsComment() && o.comments == nil {
+					o.comments = remoteComments(ctx, language)
+				}
+			}
+			current := filepath.Base(language.GetDefaultVersion(ctx).Location)
+			o.lastUsed = make(map[string]time.Time)
+			for _, iv := range installed {
+				if t, ok := records.LastUsed(language.Name(), iv.Origin); ok {
+					o.lastUsed[iv.Origin] = t
+				} else if info, err := os.Lstat(iv.Location); err == nil {
+					// 从未通过 gvm exec 使用过的版本以安装时间为准，避免刚安装的版本被清理
+					o.lastUsed[iv.Origin] = info.ModTime()
+				}
+			}
+
+			prune, err := selectPrune(ctx, installed, current, o)
+			if err != nil {
+				return err
+			}
+			for _, iv := range prune {
+				size, err := file.DirSize(iv.Location)
+				if err != nil {
+					return err
+				}
+				if !dryRun {
+					if err := language.Uninstall(ctx, iv.Origin); err != nil {
+						return fmt.Errorf("failed to uninstall %s %s: %w", language.Name(), iv.Origin, err)
+					}
+				}
+				reclaimed += size
+				action := "Removed"
+				if dryRun {
+					action = "Would remove"
+				}
+				_, _ = fmt.Fprintf(out, "%s %s %s (%s)\n",
+					action, language.Name(), iv.Version.String(), formatter.BinaryBytes(float64(size)))
+			}
+		}
+
+		if dryRun {
+			_, _ = fmt.Fprintf(out, "Would reclaim %s\n", formatter.BinaryBytes(float64(reclaimed)))
+		} else {
+			_, _ = fmt.Fprintf(out, "Reclaimed %s\n", formatter.BinaryBytes(float64(reclaimed)))
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// selectPrune 返回不被任何策略保留的版本，current 为当前默认版本的目录名；
+// 没有匹配已安装版本的固定版本会被跳过，缺少远程版本说明而无法判断时返回错误，避免误删项目使用的版本
+func selectPrune(
+	ctx context.Context, installed []*core.InstalledVersion, current string, opts pruneOptions,
+) ([]*core.InstalledVersion, error) {
+	sorted := make([]*core.InstalledVersion, len(installed))
+	copy(sorted, installed)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[j].Version.LessThan(sorted[i].Version)
+	})
+
+	kept := make(map[string]bool)
+	kept[current] = true
+
+	if opts.keep > 0 {
+		for i := 0; i < opts.keep && i < len(sorted); i++ {
+			kept[sorted[i].Origin] = true
+		}
+	}
+
+	if opts.keepLatestPatch {
+		seen := make(map[string]bool)
+		for _, iv := range sorted {
+			segments := iv.Version.Segments()
+			minor := fmt.Sprintf("%d.%d", segments[0], segments[1])
+			if !seen[minor] {
+				seen[minor] = true
+				kept[iv.Origin] = true
+			}
+		}
+	}
+
+	if len(opts.pinned) > 0 {
+		logger := log.GetLogger(ctx)
+		candidates := make([]*match.Candidate, 0, len(sorted))
+		byCandidate := make(map[*match.Candidate]string, len(sorted))
+		for _, iv := range sorted {
+			c := &match.Candidate{Version: iv.Version, Comment: opts.comments[iv.Version.String()]}
+			candidates = append(candidates, c)
+			byCandidate[c] = iv.Origin
+		}
+		for _, pin := range opts.pinned {
+			spec, err := match.ParseSpec(pin.Version)
+			if err != nil {
+				logger.Warnf("Skip version pinned in %s: %v", pin.File, err)
+				continue
+			}
+			if spec.IsSystem() {
+				continue
+			}
+			c, err := spec.Match(candidates)
+			if err != nil && spec.NeedsComment() && len(opts.comments) == 0 {
+				return nil, fmt.Errorf("can not resolve %s pinned in %s without remote versions: %w", pin.Version, pin.File, err)
+			}
+			if err != nil {
+				logger.Warnf("Skip %s pinned in %s, it matches no installed version", pin.Version, pin.File)
+				continue
+			}
+			kept[byCandidate[c]] = true
+		}
+	}
+
+	if opts.unusedFor > 0 {
+		for _, iv := range sorted {
+			if t, ok := opts.lastUsed[iv.Origin]; ok && opts.now.Sub(t) < opts.unusedFor {
+				kept[iv.Origin] = true
+			}
+		}
+	}
+
+	res := make([]*core.InstalledVersion, 0)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if !kept[sorted[i].Origin] {
+			res = append(res, sorted[i])
+		}
+	}
+	return res, nil
+}
+
+// parsePeriod 解析时间段，在 time.ParseDuration 的基础上支持 d（天）和 w（周）
+func parsePeriod(s string) (time.Duration, error) {
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	for suffix, unit := range units {
+		if n, found := strings.CutSuffix(s, suffix); found {
+			v, err := strconv.Atoi(n)
+			if err != nil || v <= 0 {
+				return 0, fmt.Errorf("invalid period %s", s)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid period %s", s)
+	}
+	return d, nil
+}
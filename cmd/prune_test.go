@@ -0,0 +1,174 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/project"
+)
+
+func TestSelectPrune(t *testing.T) {
+	installed := make([]*core.InstalledVersion, 0)
+	for _, v := range []string{"1.20.1", "1.20.3", "1.21.0", "1.21.4", "1.22.0", "1.19.2"} {
+		installed = append(installed, &core.InstalledVersion{
+			Version: goversion.Must(goversion.NewVersion(v)),
+			Origin:  v,
+		})
+	}
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		current string
+		opts    pruneOptions
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "keep-newest",
+			current: "1.19.2",
+			opts:    pruneOptions{keep: 2},
+			want:    []string{"1.20.1", "1.20.3", "1.21.0"},
+		},
+		{
+			name: "latest-patch",
+			opts: pruneOptions{keep: -1, keepLatestPatch: true},
+			want: []string{"1.20.1", "1.21.0"},
+		},
+		{
+			name:    "pinned",
+			current: "1.22.0",
+			opts: pruneOptions{keep: -1, pinned: []project.Pin{
+				{Version: "1.20", File: "a/.go-version"}, {Version: "v1.19.2", File: "b/go.mod"},
+			}},
+			want: []string{"1.20.1", "1.21.0", "1.21.4"},
+		},
+		{
+			// 没有安装的固定版本不需要保护，跳过后继续按其他固定版本清理
+			name:    "pinned-not-installed",
+			current: "1.22.0",
+			opts: pruneOptions{keep: -1, pinned: []project.Pin{
+				{Version: "2.0", File: "c/.go-version"}, {Version: "1.21", File: "a/.go-version"},
+			}},
+			want: []string{"1.19.2", "1.20.1", "1.20.3", "1.21.0"},
+		},
+		{
+			name:    "pinned-invalid",
+			current: "1.22.0",
+			opts:    pruneOptions{keep: -1, pinned: []project.Pin{{Version: "1.x", File: "d/.go-version"}}},
+			want:    []string{"1.19.2", "1.20.1", "1.20.3", "1.21.0", "1.21.4"},
+		},
+		{
+			// 离线时没有远程版本的说明，lts 固定的版本无法解析，不能当作没有固定而删除
+			name:    "pinned-lts-offline",
+			opts:    pruneOptions{keep: -1, pinned: []project.Pin{{Version: "lts/*", File: "e/.nvmrc"}}},
+			wantErr: "can not resolve lts/* pinned in e/.nvmrc without remote versions",
+		},
+		{
+			name: "pinned-lts",
+			opts: pruneOptions{
+				keep:     -1,
+				pinned:   []project.Pin{{Version: "lts/*", File: "e/.nvmrc"}},
+				comments: map[string]string{"1.21.4": "LTS: Iron"},
+			},
+			want: []string{"1.19.2", "1.20.1", "1.20.3", "1.21.0", "1.22.0"},
+		},
+		{
+			name: "unused-for",
+			opts: pruneOptions{
+				keep:      -1,
+				unusedFor: 90 * 24 * time.Hour,
+				now:       now,
+				lastUsed: map[string]time.Time{
+					"1.21.4": now.Add(-24 * time.Hour),
+					"1.20.3": now.Add(-100 * 24 * time.Hour),
+					"1.19.2": now.Add(-89 * 24 * time.Hour),
+				},
+			},
+			want: []string{"1.20.1", "1.20.3", "1.21.0", "1.22.0"},
+		},
+		{
+			name:    "combined",
+			current: "1.20.1",
+			opts:    pruneOptions{keep: 1, keepLatestPatch: true, pinned: []project.Pin{{Version: "1.21.0"}}},
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prune, err := selectPrune(context.Background(), installed, tt.current, tt.opts)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			got := make([]string, 0)
+			for _, iv := range prune {
+				got = append(got, iv.Origin)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPruneRejectsKeepBelowOne(t *testing.T) {
+	for _, keep := range []string{"0", "-1"} {
+		t.Run(keep, func(t *testing.T) {
+			c := NewPruneCmd()
+			c.SetArgs([]string{"go", "--keep", keep, "--dry-run"})
+			buf := new(bytes.Buffer)
+			c.SetOut(buf)
+			c.SetErr(buf)
+			err := c.Execute()
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "--keep must be at least 1")
+			}
+		})
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "90d", want: 90 * 24 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "36h", want: 36 * time.Hour},
+		{in: "0d", wantErr: true},
+		{in: "xd", wantErr: true},
+		{in: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parsePeriod(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
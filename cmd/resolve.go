@@ -0,0 +1,150 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/match"
+)
+
+// parseSpec 解析版本说明符，gvm alias 设置的别名会先展开为其指向的版本
+func parseSpec(language core.Language, version string) (*match.Spec, error) {
+	if target, ok := core.GetConfig().Aliases[language.Name()][version]; ok {
+		return match.ParseAliasSpec(version, target)
+	}
+	return match.ParseSpec(version)
+}
+
+// notInstalledError 返回版本未安装的错误，别名会说明其指向的版本
+func notInstalledError(language core.Language, spec *match.Spec) error {
+	if spec.Alias() != "" {
+		return fmt.Errorf("alias %s points to %s %s, which is not installed", spec.Alias(), language.Name(), spec)
+	}
+	return fmt.Errorf("%s %s is not installed", language.Name(), spec)
+}
+
+// resolveRemote 按版本说明符在远程版本中选择要安装的版本
+func resolveRemote(ctx context.Context, language core.Language, version string) (*core.RemoteVersion, error) {
+	spec, err := remoteSpec(language, version)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := language.ListRemoteVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return matchRemote(spec, versions)
+}
+
+// remoteSpec 解析要安装的版本说明符，system 不能用于安装
+func remoteSpec(language core.Language, version string) (*match.Spec, error) {
+	spec, err := parseSpec(language, version)
+	if err != nil {
+		return nil, err
+	}
+	if spec.IsSystem() {
+		return nil, fmt.Errorf("%s can not be installed, it refers to the toolchain found in PATH", version)
+	}
+	return spec, nil
+}
+
+// matchRemote 在已经获取的远程版本中选择满足说明符的版本
+func matchRemote(spec *match.Spec, versions []*core.RemoteVersion) (*core.RemoteVersion, error) {
+	candidates := make([]*match.Candidate, 0, len(versions))
+	byCandidate := make(map[*match.Candidate]*core.RemoteVersion, len(versions))
+	for _, rv := range versions {
+		c := &match.Candidate{Version: rv.Version, Comment: rv.Comment}
+		candidates = append(candidates, c)
+		byCandidate[c] = rv
+	}
+	c, err := spec.Match(candidates)
+	if err != nil {
+		return nil, err
+	}
+	return byCandidate[c], nil
+}
+
+// resolveInstalled 按版本说明符在已安装版本中选择一个版本，目录名完全一致时优先
+func resolveInstalled(ctx context.Context, language core.Language, spec *match.Spec) (*core.InstalledVersion, error) {
+	installed, err := language.ListInstalledVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, iv := range installed {
+		if iv.Origin == spec.String() || iv.Version.String() == spec.String() {
+			return iv, nil
+		}
+	}
+	candidates, byCandidate := installedCandidates(ctx, language, spec, installed)
+	c, err := spec.Match(candidates)
+	if err != nil {
+		return nil, notInstalledError(language, spec)
+	}
+	return byCandidate[c], nil
+}
+
+// resolveInstalledAll 返回所有满足版本说明符的已安装版本（从小到大）
+func resolveInstalledAll(ctx context.Context, language core.Language, spec *match.Spec) ([]*core.InstalledVersion, error) {
+	installed, err := language.ListInstalledVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidates, byCandidate := installedCandidates(ctx, language, spec, installed)
+	matched, err := spec.MatchAll(candidates)
+	if err != nil {
+		return nil, notInstalledError(language, spec)
+	}
+	res := make([]*core.InstalledVersion, 0, len(matched))
+	for _, c := range matched {
+		res = append(res, byCandidate[c])
+	}
+	return res, nil
+}
+
+func installedCandidates(
+	ctx context.Context,
+	language core.Language,
+	spec *match.Spec,
+	installed []*core.InstalledVersion,
+) ([]*match.Candidate, map[*match.Candidate]*core.InstalledVersion) {
+	var comments map[string]string
+	if spec.NeedsComment() {
+		comments = remoteComments(ctx, language)
+	}
+	candidates := make([]*match.Candidate, 0, len(installed))
+	byCandidate := make(map[*match.Candidate]*core.InstalledVersion, len(installed))
+	for _, iv := range installed {
+		c := &match.Candidate{Version: iv.Version, Comment: comments[iv.Version.String()]}
+		candidates = append(candidates, c)
+		byCandidate[c] = iv
+	}
+	return candidates, byCandidate
+}
+
+// remoteComments 返回远程版本的说明（如 LTS 代号），已安装版本本身不记录这些信息；获取失败时返回空
+func remoteComments(ctx context.Context, language core.Language) map[string]string {
+	res := make(map[string]string)
+	versions, err := language.ListRemoteVersions(ctx)
+	if err != nil {
+		return res
+	}
+	for _, rv := range versions {
+		res[rv.Version.String()] = rv.Comment
+	}
+	return res
+}
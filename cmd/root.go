@@ -28,7 +28,8 @@ import (
 )
 
 var (
-	debug bool
+	debug  bool
+	output = outputTable
 )
 
 func NewRootCmd() *cobra.Command {
@@ -40,6 +41,9 @@ func NewRootCmd() *cobra.Command {
 			ctx := cmd.Context()
 			if cmd.Name() == "ui" {
 				ctx = context.WithValue(ctx, core.ContextLogWriterKey, io.Discard)
+			} else if output != outputTable {
+				// 结构化输出时日志写入标准错误，保证标准输出可以被脚本直接解析
+				ctx = context.WithValue(ctx, core.ContextLogWriterKey, os.Stderr)
 			} else {
 				ctx = context.WithValue(ctx, core.ContextLogWriterKey, os.Stdout)
 			}
@@ -64,8 +68,21 @@ func NewRootCmd() *cobra.Command {
 		NewCmdVersion(),
 		NewAddAddonCmd(),
 		NewSetLanguageCmd(),
+		NewVerifyCmd(),
+		NewLinkCmd(),
+		NewMigrateCmd(),
+		NewUnuseCmd(),
+		NewPruneCmd(),
+		NewExecCmd(),
+		NewUpgradeCmd(),
+		NewOutdatedCmd(),
+		NewEOLCmd(),
+		NewAliasCmd(),
+		NewAddonCmd(),
+		NewDoctorCmd(),
 	)
 	cmd.PersistentFlags().BoolVarP(&debug, "debug", "d", false, "debug mode")
+	cmd.PersistentFlags().StringVarP(&output, "output", "o", outputTable, "Output format: table, json, yaml or plain")
 
 	return cmd
 }
@@ -88,14 +88,26 @@ func TestNewRootCmd(t *testing.T) {
 	expectedSubCommands := []string{
 		"ls-remote",
 		"ls",
+		"outdated",
+		"eol",
+		"alias",
+		"addon",
+		"doctor",
+		"prune",
 		"use",
 		"install",
 		"uninstall",
+		"unuse",
+		"upgrade",
 		"current",
+		"exec",
 		"ui",
 		"version",
 		"set-language",
 		"add",
+		"verify",
+		"link",
+		"migrate",
 	}
 
 	if len(cmd.Commands()) != len(expectedSubCommands) {
@@ -0,0 +1,54 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// newTableWriter 创建命令行输出使用的无边框表格
+func newTableWriter(w io.Writer) table.Writer {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.Style{
+		Name: "custom",
+		Box: table.BoxStyle{
+			BottomLeft:       "-",
+			BottomRight:      "-",
+			BottomSeparator:  "-",
+			Left:             "",
+			LeftSeparator:    "",
+			MiddleHorizontal: "-",
+			MiddleSeparator:  "",
+			PaddingLeft:      " ",
+			PaddingRight:     " ",
+			Right:            "",
+			RightSeparator:   "",
+			TopLeft:          "-",
+			TopRight:         "-",
+			TopSeparator:     "-",
+			UnfinishedRow:    " ",
+		},
+		Options: table.Options{
+			DrawBorder:      false,
+			SeparateColumns: false,
+			SeparateHeader:  true,
+			SeparateRows:    false,
+		},
+	})
+	return t
+}
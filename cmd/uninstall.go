@@ -15,7 +15,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
+	"sort"
 
 	"github.com/toodofun/gvm/internal/core"
 
@@ -23,26 +26,110 @@ import (
 )
 
 func NewUninstallCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "uninstall <lang> <version>",
+	cmd := &cobra.Command{
+		Use:   "uninstall <lang> [<version>]",
 		Short: "Uninstall a specific version of a language",
+		Long: "Uninstall a specific version of a language.\n" +
+			"A partial version such as 1.20 removes every installed 1.20.x release.",
+		Example: "  gvm uninstall go 1.20.3\n" +
+			"  gvm uninstall go 1.20\n" +
+			"  gvm uninstall node --all-but-latest",
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) != 2 {
-				return fmt.Errorf("requires two arguments: <lang> <version>")
+			if len(args) < 1 || len(args) > 2 {
+				return fmt.Errorf("requires arguments: <lang> [<version>]")
 			}
 			return nil
 		},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			lang := args[0]
-			version := args[1]
-			ctx := cmd.Context()
-
-			language, exists := core.GetLanguage(lang)
-			if !exists {
-				return cmd.Help()
+	}
+
+	var (
+		force        bool
+		allButLatest bool
+	)
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Allow removing the current version")
+	cmd.Flags().BoolVar(&allButLatest, "all-but-latest", false, "Remove all matched versions except the latest one")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if len(args) == 1 && !allButLatest {
+			return fmt.Errorf("requires a version or --all-but-latest")
+		}
+
+		language, exists := core.GetLanguage(args[0])
+		if !exists {
+			return cmd.Help()
+		}
+
+		pattern := ""
+		if len(args) > 1 {
+			pattern = args[1]
+		}
+		targets, err := uninstallTargets(ctx, language, pattern, allButLatest)
+		if err != nil {
+			return err
+		}
+
+		current := filepath.Base(language.GetDefaultVersion(ctx).Location)
+		for _, v := range targets {
+			if v == current && !force {
+				return fmt.Errorf("%s %s is the current version, switch with `gvm use` first or pass --force",
+					language.Name(), v)
 			}
+		}
 
-			return language.Uninstall(ctx, version)
-		},
+		for _, v := range targets {
+			if err := language.Uninstall(ctx, v); err != nil {
+				return fmt.Errorf("failed to uninstall %s %s: %w", language.Name(), v, err)
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Uninstalled %s %s\n", language.Name(), v)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// uninstallTargets 返回需要卸载的已安装版本，pattern 为空时匹配全部版本
+func uninstallTargets(ctx context.Context, language core.Language, pattern string, allButLatest bool) ([]string, error) {
+	installed, err := language.ListInstalledVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(installed) == 0 {
+		return nil, fmt.Errorf("no %s version installed", language.Name())
+	}
+
+	// 目录名可能与规范化后的版本号不同（如 1.22 与 1.22.0），卸载时使用目录名
+	matched := make([]string, 0, len(installed))
+	if len(pattern) == 0 {
+		sort.SliceStable(installed, func(i, j int) bool {
+			return installed[i].Version.LessThan(installed[j].Version)
+		})
+		for _, iv := range installed {
+			matched = append(matched, iv.Origin)
+		}
+	} else {
+		spec, err := parseSpec(language, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if spec.IsSystem() {
+			return nil, fmt.Errorf("the system toolchain is not managed by gvm and can not be uninstalled")
+		}
+		res, err := resolveInstalledAll(ctx, language, spec)
+		if err != nil {
+			return nil, err
+		}
+		for _, iv := range res {
+			matched = append(matched, iv.Origin)
+		}
+	}
+
+	if allButLatest {
+		if len(matched) <= 1 {
+			return nil, nil
+		}
+		matched = matched[:len(matched)-1]
 	}
+	return matched, nil
 }
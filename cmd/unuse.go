@@ -0,0 +1,61 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/toodofun/gvm/internal/core"
+
+	"github.com/spf13/cobra"
+)
+
+func NewUnuseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unuse <lang>",
+		Short: "Unset the default version of a language and fall back to the system toolchain",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("requires one argument: <lang>")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			language, exists := core.GetLanguage(args[0])
+			if !exists {
+				return cmd.Help()
+			}
+			return unsetDefault(cmd.Context(), cmd.OutOrStdout(), language)
+		},
+	}
+}
+
+// unsetDefault 取消语言的默认版本，gvm unuse 与 gvm use <lang> system 共用；
+// 提示只在表格输出时写入 w，避免混入 json、yaml 等结构化输出
+func unsetDefault(ctx context.Context, w io.Writer, language core.Language) error {
+	unsetter, ok := language.(core.DefaultUnsetter)
+	if !ok {
+		return fmt.Errorf("language %s does not support unsetting the default version", language.Name())
+	}
+	if err := unsetter.UnsetDefaultVersion(ctx); err != nil {
+		return err
+	}
+	if output == outputTable {
+		_, _ = fmt.Fprintln(w, "已取消默认版本，执行 \"source ~/.gvmrc\" 或重新打开终端以生效")
+	}
+	return nil
+}
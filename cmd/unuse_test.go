@@ -0,0 +1,57 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+)
+
+// unsetLanguage 支持取消默认版本的语言，其余方法由嵌入的接口提供
+type unsetLanguage struct {
+	core.Language
+	unset int
+}
+
+func (u *unsetLanguage) Name() string {
+	return "fake"
+}
+
+func (u *unsetLanguage) UnsetDefaultVersion(ctx context.Context) error {
+	u.unset++
+	return nil
+}
+
+func TestUnsetDefault(t *testing.T) {
+	defer func() { output = outputTable }()
+	lang := &unsetLanguage{}
+
+	var buf bytes.Buffer
+	require.NoError(t, unsetDefault(context.Background(), &buf, lang))
+	assert.Contains(t, buf.String(), "已取消默认版本")
+
+	// 结构化输出时不输出提示
+	output = outputJSON
+	buf.Reset()
+	require.NoError(t, unsetDefault(context.Background(), &buf, lang))
+	assert.Empty(t, buf.String())
+	assert.Equal(t, 2, lang.unset)
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/log"
+	"github.com/toodofun/gvm/internal/util/match"
+	"github.com/toodofun/gvm/languages"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/spf13/cobra"
+)
+
+func NewUpgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade [<lang>]",
+		Short: "Upgrade the default version of a language to the latest patch release",
+		Example: "  gvm upgrade go\n" +
+			"  gvm upgrade node --minor --remove-old\n" +
+			"  gvm upgrade --all",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return fmt.Errorf("accepts at most one argument: [<lang>]")
+			}
+			return nil
+		},
+	}
+
+	var (
+		all       bool
+		minor     bool
+		removeOld bool
+	)
+	cmd.Flags().BoolVar(&all, "all", false, "Upgrade every language that has a default version")
+	cmd.Flags().BoolVar(&minor, "minor", false, "Allow upgrading to a newer minor version")
+	cmd.Flags().BoolVar(&removeOld, "remove-old", false, "Uninstall the previous default version after upgrading")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		out := cmd.OutOrStdout()
+
+		var langs []string
+		switch {
+		case all && len(args) > 0:
+			return fmt.Errorf("--all can not be used together with a language")
+		case all:
+			langs = core.GetAllLanguage()
+		case len(args) == 1:
+			langs = args
+		default:
+			return fmt.Errorf("requires a language or --all")
+		}
+
+		failed := 0
+		for _, name := range langs {
+			language, exists := core.GetLanguage(name)
+			if !exists {
+				return cmd.Help()
+			}
+			if err := upgrade(ctx, out, language, minor, removeOld); err != nil {
+				if !all {
+					return err
+				}
+				_, _ = fmt.Fprintf(out, "Failed to upgrade %s: %v\n", language.Name(), err)
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d language(s) failed to upgrade", failed)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// upgrade 将语言的默认版本升级到同一版本线上的最新版本
+func upgrade(ctx context.Context, out io.Writer, language core.Language, minor, removeOld bool) error {
+	logger := log.GetLogger(ctx)
+	current := language.GetDefaultVersion(ctx)
+	if len(current.Location) == 0 {
+		_, _ = fmt.Fprintf(out, "%s: no default version set, skipped\n", language.Name())
+		return nil
+	}
+
+	remoteVersions, err := language.ListRemoteVersions(ctx)
+	if err != nil {
+		return err
+	}
+	target := selectUpgrade(current.Version, remoteVersions, minor)
+	if target == nil {
+		_, _ = fmt.Fprintf(out, "%s: %s is up to date\n", language.Name(), current.Version.String())
+		return nil
+	}
+
+	err, exist := languages.HasInstall(ctx, language, *target.Version)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		if err := language.Install(ctx, target); err != nil {
+			return err
+		}
+		if err := languages.RecordManifest(ctx, language, target.Version.String()); err != nil {
+			logger.Warnf("%v", err)
+		}
+	}
+	if err := language.SetDefaultVersion(ctx, target.Version.String()); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "Upgraded %s %s -> %s\n", language.Name(), current.Version.String(), target.Version.String())
+
+	if removeOld {
+		old := filepath.Base(current.Location)
+		if err := language.Uninstall(ctx, old); err != nil {
+			return fmt.Errorf("failed to uninstall %s %s: %w", language.Name(), old, err)
+		}
+		_, _ = fmt.Fprintf(out, "Uninstalled %s %s\n", language.Name(), old)
+	}
+	return nil
+}
+
+// selectUpgrade 返回与 current 主次版本相同（minor 为 true 时只要求主版本相同）的最新正式版本，
+// 没有更新的版本时返回 nil
+func selectUpgrade(current *goversion.Version, remoteVersions []*core.RemoteVersion, minor bool) *core.RemoteVersion {
+	segments := current.Core().Segments()
+	var target *core.RemoteVersion
+	for _, rv := range newerReleases(current, remoteVersions) {
+		s := rv.Version.Segments()
+		if s[0] != segments[0] || (!minor && s[1] != segments[1]) {
+			continue
+		}
+		if target == nil || rv.Version.Core().GreaterThan(target.Version.Core()) {
+			target = rv
+		}
+	}
+	return target
+}
+
+// newerReleases 返回核心版本号高于 current 的正式版本，upgrade 与 outdated 共用，
+// Java 等带发行版后缀（如 21.0.5-zulu-ab12）的版本不视为预发布版本
+func newerReleases(current *goversion.Version, remoteVersions []*core.RemoteVersion) []*core.RemoteVersion {
+	cur := current.Core()
+	res := make([]*core.RemoteVersion, 0)
+	for _, rv := range remoteVersions {
+		if match.IsPrerelease(rv.Version) || !rv.Version.Core().GreaterThan(cur) {
+			continue
+		}
+		res = append(res, rv)
+	}
+	return res
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/toodofun/gvm/internal/core"
+)
+
+func TestSelectUpgrade(t *testing.T) {
+	remote := make([]*core.RemoteVersion, 0)
+	for _, v := range []string{"1.20.1", "1.20.5", "1.21.0", "1.21.3", "1.22.0-rc1", "2.0.0",
+		"17.0.9-zulu-ab12", "17.0.13-zulu-cd34", "21.0.5-zulu-ef56"} {
+		remote = append(remote, &core.RemoteVersion{Version: goversion.Must(goversion.NewVersion(v))})
+	}
+
+	tests := []struct {
+		name    string
+		current string
+		minor   bool
+		want    string
+	}{
+		{name: "patch", current: "1.20.1", want: "1.20.5"},
+		{name: "minor", current: "1.20.1", minor: true, want: "1.21.3"},
+		{name: "up-to-date", current: "1.21.3", want: ""},
+		{name: "linked", current: "1.20.1+system", want: "1.20.5"},
+		{name: "no-major-bump", current: "1.21.3", minor: true, want: ""},
+		{name: "java", current: "17.0.9-zulu-ab12", want: "17.0.13-zulu-cd34"},
+		{name: "java-up-to-date", current: "17.0.13-zulu-cd34", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectUpgrade(goversion.Must(goversion.NewVersion(tt.current)), remote, tt.minor)
+			if tt.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			if assert.NotNil(t, got) {
+				assert.Equal(t, tt.want, got.Version.String())
+			}
+		})
+	}
+}
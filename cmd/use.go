@@ -16,8 +16,11 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/color"
+	"github.com/toodofun/gvm/internal/util/eol"
 
 	"github.com/spf13/cobra"
 )
@@ -41,10 +44,29 @@ func NewUseCmd() *cobra.Command {
 				return cmd.Help()
 			}
 
-			if err := language.SetDefaultVersion(cmd.Context(), version); err != nil {
+			spec, err := parseSpec(language, version)
+			if err != nil {
+				return err
+			}
+			if spec.IsSystem() {
+				return unsetDefault(cmd.Context(), cmd.OutOrStdout(), language)
+			}
+			iv, err := resolveInstalled(cmd.Context(), language, spec)
+			if err != nil {
+				return err
+			}
+
+			if err := language.SetDefaultVersion(cmd.Context(), iv.Origin); err != nil {
 				return err
 			}
 			fmt.Println("已设置默认版本，执行 \"source ~/.gvmrc\" 或重新打开终端以生效")
+			switch status := eol.Status(eol.Load(language.Name()), iv.Version, time.Now()); status {
+			case "":
+			case "EOL":
+				fmt.Println(color.YellowFont(fmt.Sprintf("警告：%s %s 所在的版本线已停止维护（EOL）", language.Name(), iv.Origin)))
+			default:
+				fmt.Println(color.YellowFont(fmt.Sprintf("警告：%s %s 所在的版本线即将停止维护（%s）", language.Name(), iv.Origin, status)))
+			}
 			return nil
 		},
 	}
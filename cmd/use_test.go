@@ -24,10 +24,13 @@ import (
 	"github.com/toodofun/gvm/cmd"
 
 	"github.com/toodofun/gvm/internal/core"
+
+	goversion "github.com/hashicorp/go-version"
 )
 
 // 模拟 core.GetLanguage 返回的语言接口
 type fakeLanguage struct {
+	installed               []string
 	setDefaultVersionCalled bool
 	versionPassed           string
 	setDefaultVersionErr    error
@@ -43,8 +46,15 @@ func (f *fakeLanguage) ListRemoteVersions(ctx context.Context) ([]*core.RemoteVe
 }
 
 func (f *fakeLanguage) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVersion, error) {
-	//TODO implement me
-	panic("implement me")
+	res := make([]*core.InstalledVersion, 0, len(f.installed))
+	for _, v := range f.installed {
+		res = append(res, &core.InstalledVersion{
+			Version:  goversion.Must(goversion.NewVersion(v)),
+			Origin:   v,
+			Location: "/gvm/fake/" + v,
+		})
+	}
+	return res, nil
 }
 
 func (f *fakeLanguage) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
@@ -97,15 +107,32 @@ func TestNewUseCmd(t *testing.T) {
 			name:        "设置默认版本成功",
 			args:        []string{"golang", "1.20"},
 			langExists:  true,
-			fakeLang:    &fakeLanguage{},
+			fakeLang:    &fakeLanguage{installed: []string{"1.19.13", "1.20.1", "1.20.14"}},
 			wantErr:     false,
-			wantVersion: "1.20",
+			wantVersion: "1.20.14",
+		},
+		{
+			name:        "按约束解析已安装版本",
+			args:        []string{"golang", "~1.19"},
+			langExists:  true,
+			fakeLang:    &fakeLanguage{installed: []string{"1.19.13", "1.20.1", "1.20.14"}},
+			wantErr:     false,
+			wantVersion: "1.19.13",
+		},
+		{
+			name:       "版本未安装返回错误",
+			args:       []string{"golang", "1.21"},
+			langExists: true,
+			fakeLang:   &fakeLanguage{installed: []string{"1.20.1"}},
+			wantErr:    true,
+			wantErrMsg: "is not installed",
 		},
 		{
 			name:       "设置默认版本失败返回错误",
 			args:       []string{"node", "18.0"},
 			langExists: true,
 			fakeLang: &fakeLanguage{
+				installed:            []string{"18.0.0"},
 				setDefaultVersionErr: errors.New("set version failed"),
 			},
 			wantErr:    true,
@@ -0,0 +1,174 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/color"
+	"github.com/toodofun/gvm/internal/util/manifest"
+	"github.com/toodofun/gvm/languages"
+
+	"github.com/spf13/cobra"
+)
+
+// maxListedFiles 每类文件最多列出的数量，node_modules 这类目录可能新增成千上万个文件
+const maxListedFiles = 10
+
+func NewVerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [<lang> [<version>]]",
+		Short: "Verify the integrity of installed versions",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 2 {
+				return fmt.Errorf("accepts at most two arguments: [<lang> [<version>]]")
+			}
+			return nil
+		},
+	}
+
+	var repair, strict bool
+	cmd.Flags().BoolVar(&repair, "repair", false, "Reinstall damaged versions")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Also treat cache files such as __pycache__ and *.pyc as extra files")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		out := cmd.OutOrStdout()
+
+		langs := core.GetAllLanguage()
+		if len(args) > 0 {
+			langs = []string{args[0]}
+		}
+
+		damaged := 0
+		for _, name := range langs {
+			language, exists := core.GetLanguage(name)
+			if !exists {
+				return cmd.Help()
+			}
+			installed, err := language.ListInstalledVersions(ctx)
+			if err != nil {
+				return err
+			}
+			for _, iv := range installed {
+				v := iv.Version.String()
+				if len(args) > 1 && args[1] != v {
+					continue
+				}
+				ok, err := verifyVersion(out, language, iv, strict)
+				if err != nil {
+					return err
+				}
+				if ok {
+					continue
+				}
+				if !repair {
+					damaged++
+					continue
+				}
+				if err := reinstall(ctx, language, v); err != nil {
+					_, _ = fmt.Fprintf(out, "  %s\n", color.RedFont(fmt.Sprintf("repair failed: %v", err)))
+					damaged++
+					continue
+				}
+				_, _ = fmt.Fprintf(out, "  %s\n", color.GreenFont("repaired"))
+			}
+		}
+
+		if damaged > 0 {
+			return fmt.Errorf("%d damaged installation(s) found, run with --repair to reinstall them", damaged)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// verifyVersion 校验单个版本并输出结果，没有清单的版本视为通过
+func verifyVersion(out io.Writer, language core.Language, iv *core.InstalledVersion, strict bool) (bool, error) {
+	name := fmt.Sprintf("%s %s", language.Name(), iv.Version.String())
+	m, err := manifest.Load(language.Name(), iv.Version.String())
+	if os.IsNotExist(err) {
+		_, _ = fmt.Fprintf(out, "%s: no manifest recorded, skipped\n", name)
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	res, err := manifest.Verify(m, iv.Location, strict)
+	if err != nil {
+		return false, err
+	}
+	if res.OK() {
+		_, _ = fmt.Fprintf(out, "%s: %s\n", name, color.GreenFont("ok"))
+		return true, nil
+	}
+
+	_, _ = fmt.Fprintf(out, "%s: %s (%d modified, %d missing, %d extra)\n",
+		name, color.RedFont("damaged"), len(res.Modified), len(res.Missing), len(res.Extra))
+	printFiles(out, "modified:", res.Modified)
+	printFiles(out, "missing: ", res.Missing)
+	printFiles(out, "extra:   ", res.Extra)
+	return false, nil
+}
+
+// printFiles 输出文件列表，最多显示 maxListedFiles 个
+func printFiles(out io.Writer, label string, files []string) {
+	for i, f := range files {
+		if i == maxListedFiles {
+			_, _ = fmt.Fprintf(out, "  %s ... and %d more\n", label, len(files)-maxListedFiles)
+			return
+		}
+		_, _ = fmt.Fprintf(out, "  %s %s\n", label, f)
+	}
+}
+
+// reinstall 卸载并重新安装指定版本，保留默认版本设置
+func reinstall(ctx context.Context, language core.Language, version string) error {
+	remoteVersions, err := language.ListRemoteVersions(ctx)
+	if err != nil {
+		return err
+	}
+	var target *core.RemoteVersion
+	for _, rv := range remoteVersions {
+		if rv.Version.String() == version {
+			target = rv
+			break
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("version %s is no longer available upstream", version)
+	}
+
+	isDefault := language.GetDefaultVersion(ctx).Version.String() == version
+	if err := language.Uninstall(ctx, version); err != nil {
+		return err
+	}
+	if err := language.Install(ctx, target); err != nil {
+		return err
+	}
+	if err := languages.RecordManifest(ctx, language, version); err != nil {
+		return err
+	}
+	if isDefault {
+		return language.SetDefaultVersion(ctx, version)
+	}
+	return nil
+}
@@ -16,7 +16,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/toodofun/gvm/internal/util/version"
 
@@ -31,9 +30,21 @@ func NewCmdVersion() *cobra.Command {
 		Long:  "Print version information for the current context",
 		Example: "Print versions for the current context " +
 			"\n\t\t gvm version",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkOutput(); err != nil {
+				return err
+			}
 			versionInfo := version.Get()
-			_, _ = fmt.Fprintf(os.Stdout, "%+v\n", versionInfo)
+			out := cmd.OutOrStdout()
+			switch {
+			case structuredOutput():
+				return writeStructured(out, versionInfo)
+			case output == outputPlain:
+				_, _ = fmt.Fprintln(out, versionInfo.GitVersion)
+			default:
+				_, _ = fmt.Fprintf(out, "%+v\n", versionInfo)
+			}
+			return nil
 		},
 	}
 	return cmd
@@ -0,0 +1,107 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AddonFactory 根据插件名称和数据源创建语言
+type AddonFactory func(name, dsn string) (Language, error)
+
+var (
+	addonProviders = make(map[string]AddonFactory)
+	addonErrors    = make(map[string]error)
+)
+
+// reservedNames gvm 根目录下已被占用的条目：配置文件、日志文件和声明式语言定义目录
+var reservedNames = []string{"config.json", "app.log", "languages.d"}
+
+// reservedPrefix 外部插件的可执行文件以此为前缀放在 gvm 根目录下
+const reservedPrefix = "gvm-plugin-"
+
+// ValidateName 检查语言名称能否安全地作为 gvm 根目录下的目录名：不能为空、不能以 . 开头、不能包含路径分隔符，
+// 也不能与根目录下已有的条目重名；部分文件系统不区分大小写，比较时忽略大小写
+func ValidateName(name string) error {
+	if name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid name %q, it must not be empty, start with . or contain path separators", name)
+	}
+	for _, reserved := range reservedNames {
+		if strings.EqualFold(name, reserved) {
+			return fmt.Errorf("invalid name %q, it is reserved by gvm", name)
+		}
+	}
+	if len(name) >= len(reservedPrefix) && strings.EqualFold(name[:len(reservedPrefix)], reservedPrefix) {
+		return fmt.Errorf("invalid name %q, it must not start with %s", name, reservedPrefix)
+	}
+	return nil
+}
+
+// RegisterAddonProvider 注册插件类型，由各插件包在 init 中调用
+func RegisterAddonProvider(provider string, factory AddonFactory) {
+	addonProviders[provider] = factory
+}
+
+// GetAddonProvider 返回插件类型对应的创建函数
+func GetAddonProvider(provider string) (AddonFactory, bool) {
+	factory, ok := addonProviders[provider]
+	return factory, ok
+}
+
+// GetAddonProviders 返回所有已注册的插件类型
+func GetAddonProviders() []string {
+	res := make([]string, 0, len(addonProviders))
+	for k := range addonProviders {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// NewAddon 按配置创建插件语言，不会注册
+func NewAddon(item LanguageItem) (Language, error) {
+	if err := ValidateName(item.Name); err != nil {
+		return nil, err
+	}
+	factory, ok := GetAddonProvider(item.Provider)
+	if !ok {
+		return nil, fmt.Errorf("unsupported addon provider %s, supported: %v", item.Provider, GetAddonProviders())
+	}
+	return factory(item.Name, item.DataSourceName)
+}
+
+// LoadAddons 按配置文件中的 addon 列表创建并注册语言，创建失败的条目记录在 GetAddonErrors 中
+func LoadAddons() {
+	addonErrors = make(map[string]error)
+	for _, item := range GetConfig().Addon {
+		if _, exists := GetLanguage(item.Name); exists {
+			addonErrors[item.Name] = fmt.Errorf("name %s conflicts with a registered language", item.Name)
+			continue
+		}
+		lang, err := NewAddon(item)
+		if err != nil {
+			addonErrors[item.Name] = err
+			continue
+		}
+		RegisterLanguage(lang)
+	}
+}
+
+// GetAddonErrors 返回最近一次 LoadAddons 中加载失败的插件及原因
+func GetAddonErrors() map[string]error {
+	return addonErrors
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/util/file"
+)
+
+func TestLoadAddons(t *testing.T) {
+	root := t.TempDir()
+	origRoot := GetRootDir
+	defer func() { GetRootDir = origRoot }()
+	GetRootDir = func() string { return root }
+	languages = map[string]Language{"go": &mockLanguage{name: "go"}}
+	defer func() { delete(addonProviders, "test") }()
+
+	RegisterAddonProvider("test", func(name, dsn string) (Language, error) {
+		if dsn == "" {
+			return nil, fmt.Errorf("dsn must not be empty")
+		}
+		return &mockLanguage{name: name}, nil
+	})
+	require.NoError(t, file.WriteJSONFile(GetConfigPath(), &Config{Addon: []LanguageItem{
+		{Name: "kubectl", Provider: "test", DataSourceName: "kubernetes/kubernetes"},
+		{Name: "broken", Provider: "test"},
+		{Name: "go", Provider: "test", DataSourceName: "golang/go"},
+		{Name: "unknown", Provider: "gitlab", DataSourceName: "a/b"},
+		{Name: "../escape", Provider: "test", DataSourceName: "a/b"},
+	}}))
+
+	LoadAddons()
+
+	_, exists := GetLanguage("kubectl")
+	assert.True(t, exists)
+	_, exists = GetLanguage("broken")
+	assert.False(t, exists)
+	errs := GetAddonErrors()
+	assert.Len(t, errs, 4)
+	assert.EqualError(t, errs["broken"], "dsn must not be empty")
+	assert.EqualError(t, errs["go"], "name go conflicts with a registered language")
+	assert.Contains(t, errs["unknown"].Error(), "unsupported addon provider gitlab")
+	assert.ErrorContains(t, errs["../escape"], "invalid name")
+}
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+	}{
+		{name: "kubectl"},
+		{name: "node18"},
+		{name: "my-tool"},
+		{name: "a.b"},
+		{name: "MyTool"},
+		{name: "my tool"},
+		{name: "config"},
+		{name: "gvm-plugin"},
+		{name: "", wantErr: "must not be empty"},
+		{name: "..", wantErr: "start with ."},
+		{name: "../x", wantErr: "start with ."},
+		{name: "a/b", wantErr: "path separators"},
+		{name: `a\b`, wantErr: "path separators"},
+		{name: ".hidden", wantErr: "start with ."},
+		{name: "config.json", wantErr: "reserved by gvm"},
+		{name: "Config.JSON", wantErr: "reserved by gvm"},
+		{name: "app.log", wantErr: "reserved by gvm"},
+		{name: "languages.d", wantErr: "reserved by gvm"},
+		{name: "gvm-plugin-hello", wantErr: "must not start with gvm-plugin-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateName(tt.name)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
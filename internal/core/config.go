@@ -32,6 +32,30 @@ var Version = "1.0.0-dev"
 type Config struct {
 	Language string         `json:"language"`
 	Addon    []LanguageItem `json:"addon"`
+	// Aliases 用户定义的版本别名，语言 -> 别名 -> 版本说明符
+	Aliases map[string]map[string]string `json:"aliases,omitempty"`
+	GitHub  *GitHubConfig                `json:"github,omitempty"`
+	GitLab  *GitLabConfig                `json:"gitlab,omitempty"`
+	Gitea   *GiteaConfig                 `json:"gitea,omitempty"`
+}
+
+// GitHubConfig 访问 GitHub API 的配置，环境变量 GITHUB_TOKEN、GH_TOKEN 和 GVM_GITHUB_API_URL 优先
+type GitHubConfig struct {
+	Token string `json:"token,omitempty"`
+	// APIURL GitHub Enterprise 的 API 地址，如 https://github.example.com/api/v3
+	APIURL string `json:"api_url,omitempty"`
+}
+
+// GitLabConfig GitLab 插件使用的实例地址和 token，环境变量 GVM_GITLAB_URL、GITLAB_TOKEN 优先
+type GitLabConfig struct {
+	URL   string `json:"url,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
+// GiteaConfig Gitea（包括 Forgejo）插件使用的实例地址和 token，环境变量 GVM_GITEA_URL、GITEA_TOKEN 优先
+type GiteaConfig struct {
+	URL   string `json:"url,omitempty"`
+	Token string `json:"token,omitempty"`
 }
 
 type LanguageItem struct {
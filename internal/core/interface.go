@@ -16,6 +16,9 @@ package core
 
 import (
 	"context"
+	"time"
+
+	"github.com/toodofun/gvm/internal/util/env"
 
 	"github.com/hashicorp/go-version"
 )
@@ -41,3 +44,58 @@ type InstalledVersion struct {
 	Origin   string
 	Location string
 }
+
+// LocalInstaller 支持从本地安装包或已解压目录安装的语言
+type LocalInstaller interface {
+	InstallFromFile(ctx context.Context, remoteVersion *RemoteVersion, file string) error
+	InstallFromDir(ctx context.Context, remoteVersion *RemoteVersion, dir string) error
+}
+
+// Linker 支持登记外部已安装工具链的语言，version 为空时通过执行二进制检测版本
+type Linker interface {
+	Link(ctx context.Context, name, target string, version *version.Version) (*InstalledVersion, error)
+}
+
+// DefaultUnsetter 支持取消默认版本的语言，取消后恢复使用系统中的工具链
+type DefaultUnsetter interface {
+	UnsetDefaultVersion(ctx context.Context) error
+}
+
+// ExecutableProvider 提供语言主程序的名称，用于在 PATH 中查找系统自带的工具链
+type ExecutableProvider interface {
+	Executable() string
+}
+
+// EnvProvider 提供设置默认版本时写入的环境变量，其中的路径都指向 current 目录
+type EnvProvider interface {
+	Envs() []env.KV
+}
+
+// Lifecycle 版本线的生命周期，Cycle 为版本线（如 Node 的 18、Python 的 3.8），EOL 为零值表示尚未公布
+type Lifecycle struct {
+	Cycle string    `json:"cycle"`
+	EOL   time.Time `json:"eol"`
+}
+
+// LifecycleProvider 支持从上游获取版本线生命周期的语言
+type LifecycleProvider interface {
+	FetchLifecycles(ctx context.Context) ([]*Lifecycle, error)
+}
+
+// Asset 某个版本在当前平台上使用的安装包
+type Asset struct {
+	Name string `json:"name" yaml:"name"`
+	URL  string `json:"url" yaml:"url"`
+}
+
+// AssetRejection 未被选择的安装包及原因
+type AssetRejection struct {
+	Name   string `json:"name" yaml:"name"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// AssetResolver 按平台选择安装包的语言，用于在不安装的情况下检查插件配置是否可用；
+// 没有合适的安装包时返回错误，同时返回各安装包未被选择的原因
+type AssetResolver interface {
+	ResolveAsset(ctx context.Context, remoteVersion *RemoteVersion) (*Asset, []AssetRejection, error)
+}
@@ -119,8 +119,35 @@ func (c *Client) Get(ctx context.Context, url string) ([]byte, error) {
 	return res, nil
 }
 
+// GetWithHeader 携带请求头发起 GET 请求并返回响应头，用于需要认证或分页的 API，结果不会缓存
+func (c *Client) GetWithHeader(ctx context.Context, url string, header map[string]string) ([]byte, http.Header, error) {
+	logger := log.GetLogger(ctx)
+	resp, err := c.resty.R().WithContext(ctx).SetHeaders(header).Get(url)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		if err := Body.Close(); err != nil {
+			logger.Warnf("Close body error: %s", err)
+		}
+	}(resp.Body)
+	if resp.IsError() {
+		return nil, nil, fmt.Errorf("response status: %s", resp.Status())
+	}
+	res, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, resp.Header(), nil
+}
+
 func (c *Client) Head(ctx context.Context, url string) (http.Header, int, error) {
-	resp, err := c.resty.R().WithContext(ctx).Head(url)
+	return c.HeadWithHeader(ctx, url, nil)
+}
+
+// HeadWithHeader 携带请求头发起 HEAD 请求，用于需要认证的下载地址
+func (c *Client) HeadWithHeader(ctx context.Context, url string, header map[string]string) (http.Header, int, error) {
+	resp, err := c.resty.R().WithContext(ctx).SetHeaders(header).Head(url)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -131,6 +158,11 @@ func (c *Client) Head(ctx context.Context, url string) (http.Header, int, error)
 }
 
 func (c *Client) Download(ctx context.Context, url, destPath, filename string) (string, error) {
+	return c.DownloadWithHeader(ctx, url, destPath, filename, nil)
+}
+
+// DownloadWithHeader 携带请求头下载文件，如私有仓库中的安装包
+func (c *Client) DownloadWithHeader(ctx context.Context, url, destPath, filename string, header map[string]string) (string, error) {
 	loggerWriter := log.GetWriter(ctx)
 	logger := log.GetLogger(ctx)
 
@@ -160,6 +192,7 @@ func (c *Client) Download(ctx context.Context, url, destPath, filename string) (
 
 	resp, err := checkClient.R().
 		WithContext(ctx).
+		SetHeaders(header).
 		SetHeader("Range", "bytes=0-1"). // 改为0-1，更标准
 		Head(url)
 
@@ -205,6 +238,7 @@ func (c *Client) Download(ctx context.Context, url, destPath, filename string) (
 
 	request := downloadClient.R().
 		WithContext(ctx).
+		SetHeaders(header).
 		SetDoNotParseResponse(true)
 
 	if supportsRange && existingSize > 0 {
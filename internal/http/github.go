@@ -0,0 +1,191 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/log"
+	"github.com/toodofun/gvm/internal/util/file"
+)
+
+// PublicGitHubAPIURL 为 github.com 的 API 地址。gvm 自身和 Rust 的发布版本只在 github.com 上，
+// 这些请求始终使用该地址，不受 GitHub Enterprise 配置影响；配置了 Enterprise 时 token 属于 Enterprise，不会发送给 github.com
+const PublicGitHubAPIURL = "https://api.github.com"
+
+// GitHubAPIURL 返回 GitHub API 地址，GitHub Enterprise 可以通过 GVM_GITHUB_API_URL 或配置 github.api_url 修改
+func GitHubAPIURL() string {
+	if custom := os.Getenv("GVM_GITHUB_API_URL"); custom != "" {
+		return strings.TrimSuffix(custom, "/")
+	}
+	if config := core.GetConfig().GitHub; config != nil && config.APIURL != "" {
+		return strings.TrimSuffix(config.APIURL, "/")
+	}
+	return PublicGitHubAPIURL
+}
+
+// GitHubToken 返回访问 GitHub API 使用的 token，依次读取 GITHUB_TOKEN、GH_TOKEN 和配置 github.token
+func GitHubToken() string {
+	for _, key := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := os.Getenv(key); token != "" {
+			return token
+		}
+	}
+	if config := core.GetConfig().GitHub; config != nil {
+		return config.Token
+	}
+	return ""
+}
+
+// RateLimitError GitHub API 限流错误，Reset 为限额恢复的时间
+type RateLimitError struct {
+	Limit         int
+	Remaining     int
+	Reset         time.Time
+	Authenticated bool
+}
+
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("GitHub API rate limit exceeded (%d/%d remaining), retry after %s (in %s)",
+		e.Remaining, e.Limit, e.Reset.Local().Format("15:04:05"), time.Until(e.Reset).Round(time.Second))
+	if !e.Authenticated {
+		msg += "; set GITHUB_TOKEN or GH_TOKEN to raise the limit"
+	}
+	return msg
+}
+
+// githubEntry 缓存的 GitHub 响应，ETag 用于条件请求，Link 用于分页
+type githubEntry struct {
+	ETag string `json:"etag"`
+	Link string `json:"link"`
+	Body []byte `json:"body"`
+}
+
+func (e *githubEntry) header() http.Header {
+	header := make(http.Header)
+	if e.Link != "" {
+		header.Set("Link", e.Link)
+	}
+	return header
+}
+
+// githubCachePath 返回 GitHub 响应的磁盘缓存路径，进程退出后仍可以用 ETag 发起条件请求
+func githubCachePath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(core.GetRootDir(), ".cache", "github", hex.EncodeToString(sum[:])+".json")
+}
+
+// GetGitHub 请求 GitHub API 并返回响应内容和响应头；token 为空时使用 GitHubToken，
+// 但只会发送给 GitHubAPIURL 下的地址。响应未变化（304）时使用缓存，不消耗限额
+func (c *Client) GetGitHub(ctx context.Context, url, token string) ([]byte, http.Header, error) {
+	logger := log.GetLogger(ctx)
+	key := "github:" + url
+	if val, found := c.cache.Get(key); found {
+		logger.Debugf("[cache] hit: %s", url)
+		entry := val.(*githubEntry)
+		return entry.Body, entry.header(), nil
+	}
+	if token == "" && strings.HasPrefix(url, GitHubAPIURL()+"/") {
+		token = GitHubToken()
+	}
+
+	cachePath := githubCachePath(url)
+	cached := new(githubEntry)
+	if err := file.ReadJSONFile(cachePath, cached); err != nil {
+		cached = nil
+	}
+
+	req := c.resty.R().WithContext(ctx).
+		SetHeader("Accept", "application/vnd.github+json").
+		SetHeader("User-Agent", "gvm")
+	if token != "" {
+		req.SetHeader("Authorization", "Bearer "+token)
+	}
+	if cached != nil && cached.ETag != "" {
+		req.SetHeader("If-None-Match", cached.ETag)
+	}
+	resp, err := req.Get(url)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		if err := Body.Close(); err != nil {
+			logger.Warnf("Close body error: %s", err)
+		}
+	}(resp.Body)
+
+	if resp.StatusCode() == http.StatusNotModified && cached != nil {
+		logger.Debugf("[github] not modified: %s", url)
+		c.cache.Set(key, cached, defaultCacheTTL)
+		return cached.Body, cached.header(), nil
+	}
+	if err := rateLimitError(resp.StatusCode(), resp.Header(), token != ""); err != nil {
+		return nil, nil, err
+	}
+	if resp.IsError() {
+		return nil, nil, fmt.Errorf("response status: %s", resp.Status())
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry := &githubEntry{ETag: resp.Header().Get("ETag"), Link: resp.Header().Get("Link"), Body: body}
+	c.cache.Set(key, entry, defaultCacheTTL)
+	if entry.ETag != "" {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err == nil {
+			if err := file.WriteJSONFile(cachePath, entry); err != nil {
+				logger.Debugf("Failed to cache %s: %v", url, err)
+			}
+		}
+	}
+	return body, resp.Header(), nil
+}
+
+// rateLimitError 根据 X-RateLimit-* 和 Retry-After 响应头判断请求是否被限流
+func rateLimitError(status int, header http.Header, authenticated bool) error {
+	if status != http.StatusForbidden && status != http.StatusTooManyRequests {
+		return nil
+	}
+	e := &RateLimitError{Authenticated: authenticated}
+	e.Limit, _ = strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err == nil {
+		e.Remaining = remaining
+	}
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		e.Reset = time.Now().Add(time.Duration(seconds) * time.Second)
+		return e
+	}
+	if err != nil || remaining > 0 {
+		// 没有剩余次数信息的 403 是权限问题，不是限流
+		return nil
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		e.Reset = time.Unix(reset, 0)
+	}
+	return e
+}
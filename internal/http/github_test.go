@@ -0,0 +1,105 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/toodofun/gvm/internal/testutil"
+
+	"resty.dev/v3"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGitHubTestClient() *Client {
+	return &Client{
+		resty: resty.New(),
+		cache: cache.New(defaultCacheTTL, defaultCacheTTL*2),
+	}
+}
+
+func TestGetGitHub(t *testing.T) {
+	testutil.SetRootDir(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Link", `<next>; rel="next"`)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	t.Setenv("GVM_GITHUB_API_URL", server.URL)
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	body, header, err := newGitHubTestClient().GetGitHub(context.Background(), server.URL+"/repos/a/b/releases", "")
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(body))
+	assert.Equal(t, `<next>; rel="next"`, header.Get("Link"))
+
+	// 新的进程内存缓存为空，通过磁盘上的 ETag 发起条件请求
+	body, header, err = newGitHubTestClient().GetGitHub(context.Background(), server.URL+"/repos/a/b/releases", "")
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(body))
+	assert.Equal(t, `<next>; rel="next"`, header.Get("Link"))
+	assert.Equal(t, 2, requests)
+}
+
+func TestGetGitHubRateLimit(t *testing.T) {
+	testutil.SetRootDir(t)
+
+	reset := time.Now().Add(30 * time.Minute)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"), "token must not be sent to other hosts")
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "secret")
+
+	_, _, err := newGitHubTestClient().GetGitHub(context.Background(), server.URL+"/repos/a/b/releases", "")
+	var rateLimit *RateLimitError
+	require.ErrorAs(t, err, &rateLimit)
+	assert.Equal(t, 60, rateLimit.Limit)
+	assert.Equal(t, reset.Unix(), rateLimit.Reset.Unix())
+	assert.Contains(t, err.Error(), "retry after")
+	assert.Contains(t, err.Error(), "set GITHUB_TOKEN or GH_TOKEN")
+}
+
+func TestRateLimitErrorForbidden(t *testing.T) {
+	// 没有限流信息的 403 不是限流错误
+	assert.NoError(t, rateLimitError(http.StatusForbidden, http.Header{}, false))
+	header := http.Header{}
+	header.Set("Retry-After", "60")
+	err := rateLimitError(http.StatusTooManyRequests, header, true)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "GITHUB_TOKEN")
+}
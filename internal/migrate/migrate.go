@@ -0,0 +1,273 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// Tool 描述其他版本管理工具的目录布局
+type Tool struct {
+	Name string
+	// Lang 为对应的 gvm 语言名称
+	Lang string
+	// RootEnv 为覆盖工具根目录的环境变量，DefaultRoot 为相对用户目录的默认根目录
+	RootEnv     string
+	DefaultRoot string
+	// VersionsDir 为版本目录相对工具根目录的位置
+	VersionsDir string
+	// parse 将版本目录名解析为版本号和链接名称
+	parse func(dirName string) (*goversion.Version, string, error)
+	// defaultVersion 读取工具设置的全局默认版本
+	defaultVersion func(root string) string
+	// RcMarkers 为 shell 配置文件中属于该工具的特征字符串
+	RcMarkers []string
+}
+
+// Install 为在其他工具中发现的一个已安装版本
+type Install struct {
+	Version *goversion.Version
+	// Name 不为空时作为 gvm 版本目录的构建元数据，用于区分同版本号的不同发行版
+	Name string
+	// Dir 为原始目录名，Path 为完整路径
+	Dir  string
+	Path string
+}
+
+// RcLine 为 shell 配置文件中与工具相关的一行
+type RcLine struct {
+	File string
+	Line int
+	Text string
+}
+
+var tools = []*Tool{
+	{
+		Name:           "nvm",
+		Lang:           "node",
+		RootEnv:        "NVM_DIR",
+		DefaultRoot:    ".nvm",
+		VersionsDir:    filepath.Join("versions", "node"),
+		parse:          parsePlain,
+		defaultVersion: nvmDefaultVersion,
+		RcMarkers:      []string{"NVM_DIR", "nvm.sh", "nvm/bash_completion"},
+	},
+	{
+		Name:        "pyenv",
+		Lang:        "python",
+		RootEnv:     "PYENV_ROOT",
+		DefaultRoot: ".pyenv",
+		VersionsDir: "versions",
+		parse:       parsePlain,
+		defaultVersion: func(root string) string {
+			return readFirstLine(filepath.Join(root, "version"))
+		},
+		RcMarkers: []string{"PYENV_ROOT", "pyenv init", "pyenv virtualenv-init", ".pyenv/bin"},
+	},
+	{
+		Name:        "goenv",
+		Lang:        "go",
+		RootEnv:     "GOENV_ROOT",
+		DefaultRoot: ".goenv",
+		VersionsDir: "versions",
+		parse:       parsePlain,
+		defaultVersion: func(root string) string {
+			return readFirstLine(filepath.Join(root, "version"))
+		},
+		RcMarkers: []string{"GOENV_ROOT", "goenv init", ".goenv/bin"},
+	},
+	{
+		Name:        "sdkman",
+		Lang:        "java",
+		RootEnv:     "SDKMAN_DIR",
+		DefaultRoot: ".sdkman",
+		VersionsDir: filepath.Join("candidates", "java"),
+		parse:       parseSdkman,
+		defaultVersion: func(root string) string {
+			target, err := os.Readlink(filepath.Join(root, "candidates", "java", "current"))
+			if err != nil {
+				return ""
+			}
+			return filepath.Base(target)
+		},
+		RcMarkers: []string{"SDKMAN_DIR", "sdkman-init.sh"},
+	},
+	{
+		Name:        "rbenv",
+		Lang:        "ruby",
+		RootEnv:     "RBENV_ROOT",
+		DefaultRoot: ".rbenv",
+		VersionsDir: "versions",
+		parse:       parsePlain,
+		defaultVersion: func(root string) string {
+			return readFirstLine(filepath.Join(root, "version"))
+		},
+		RcMarkers: []string{"RBENV_ROOT", "rbenv init", ".rbenv/bin"},
+	},
+}
+
+// GetTool 根据名称获取工具定义
+func GetTool(name string) (*Tool, bool) {
+	for _, t := range tools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// GetAllTools 返回所有支持迁移的工具名称
+func GetAllTools() []string {
+	res := make([]string, 0, len(tools))
+	for _, t := range tools {
+		res = append(res, t.Name)
+	}
+	return res
+}
+
+// Root 返回工具的根目录，优先使用环境变量
+func (t *Tool) Root() (string, error) {
+	if root := os.Getenv(t.RootEnv); root != "" {
+		return root, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, t.DefaultRoot), nil
+}
+
+// Scan 列出工具根目录下的已安装版本，无法识别的目录会通过 skipped 返回
+func (t *Tool) Scan(root string) (installs []*Install, skipped []string, err error) {
+	dir := filepath.Join(root, t.VersionsDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, entry := range entries {
+		// 软链接通常是别名（sdkman 的 current、pyenv 的虚拟环境），不作为版本导入
+		if entry.Type()&os.ModeSymlink != 0 || !entry.IsDir() {
+			continue
+		}
+		v, name, err := t.parse(entry.Name())
+		if err != nil {
+			skipped = append(skipped, entry.Name())
+			continue
+		}
+		installs = append(installs, &Install{
+			Version: v,
+			Name:    name,
+			Dir:     entry.Name(),
+			Path:    filepath.Join(dir, entry.Name()),
+		})
+	}
+	sort.Slice(installs, func(i, j int) bool {
+		return installs[i].Version.LessThan(installs[j].Version)
+	})
+	return installs, skipped, nil
+}
+
+// DefaultVersion 返回工具设置的全局默认版本，未设置时返回空字符串
+func (t *Tool) DefaultVersion(root string) string {
+	return t.defaultVersion(root)
+}
+
+// ScanRcFiles 在常见的 shell 配置文件中查找与工具相关的行
+func (t *Tool) ScanRcFiles(home string) []RcLine {
+	files := []string{
+		".bashrc", ".bash_profile", ".profile", ".zshrc", ".zprofile", ".zshenv",
+		filepath.Join(".config", "fish", "config.fish"),
+	}
+	res := make([]RcLine, 0)
+	for _, name := range files {
+		file := filepath.Join(home, name)
+		f, err := os.Open(file)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		line := 0
+		for scanner.Scan() {
+			line++
+			text := scanner.Text()
+			for _, marker := range t.RcMarkers {
+				if strings.Contains(text, marker) {
+					res = append(res, RcLine{File: file, Line: line, Text: strings.TrimSpace(text)})
+					break
+				}
+			}
+		}
+		_ = f.Close()
+	}
+	return res
+}
+
+// parsePlain 解析 18.17.0、v18.17.0 这类只包含版本号的目录名
+func parsePlain(dirName string) (*goversion.Version, string, error) {
+	v, err := goversion.NewVersion(dirName)
+	if err != nil {
+		return nil, "", err
+	}
+	return v, "", nil
+}
+
+// parseSdkman 解析 17.0.8-tem 这类目录名，后缀为发行版标识
+func parseSdkman(dirName string) (*goversion.Version, string, error) {
+	ver, vendor, found := strings.Cut(dirName, "-")
+	if !found || len(vendor) == 0 {
+		return nil, "", fmt.Errorf("unrecognized sdkman candidate %s", dirName)
+	}
+	v, err := goversion.NewVersion(ver)
+	if err != nil {
+		return nil, "", err
+	}
+	return v, strings.ReplaceAll(vendor, "_", "-"), nil
+}
+
+// nvmDefaultVersion 读取 nvm 的 default 别名，别名可以指向另一个别名（如 lts/hydrogen）
+func nvmDefaultVersion(root string) string {
+	alias := "default"
+	for i := 0; i < 10; i++ {
+		next := readFirstLine(filepath.Join(root, "alias", alias))
+		if len(next) == 0 {
+			if alias == "default" {
+				return ""
+			}
+			return alias
+		}
+		alias = next
+	}
+	return alias
+}
+
+// readFirstLine 读取文件中的第一个版本，pyenv 等工具允许在同一行写多个版本
+func readFirstLine(file string) string {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
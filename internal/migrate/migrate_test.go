@@ -0,0 +1,114 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mkdirs(t *testing.T, dirs ...string) {
+	t.Helper()
+	for _, d := range dirs {
+		require.NoError(t, os.MkdirAll(d, 0755))
+	}
+}
+
+func TestScan_Nvm(t *testing.T) {
+	root := t.TempDir()
+	versions := filepath.Join(root, "versions", "node")
+	mkdirs(t, filepath.Join(versions, "v20.5.0"), filepath.Join(versions, "v18.17.0"), filepath.Join(versions, "garbage"))
+	mkdirs(t, filepath.Join(root, "alias", "lts"))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "alias", "default"), []byte("lts/hydrogen\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "alias", "lts", "hydrogen"), []byte("v18.17.0\n"), 0644))
+
+	tool, ok := GetTool("nvm")
+	require.True(t, ok)
+
+	installs, skipped, err := tool.Scan(root)
+	require.NoError(t, err)
+	require.Len(t, installs, 2)
+	assert.Equal(t, "18.17.0", installs[0].Version.String())
+	assert.Equal(t, "v18.17.0", installs[0].Dir)
+	assert.Equal(t, "20.5.0", installs[1].Version.String())
+	assert.Equal(t, []string{"garbage"}, skipped)
+	assert.Equal(t, "v18.17.0", tool.DefaultVersion(root))
+}
+
+func TestScan_Sdkman(t *testing.T) {
+	root := t.TempDir()
+	candidates := filepath.Join(root, "candidates", "java")
+	mkdirs(t, filepath.Join(candidates, "17.0.8-tem"), filepath.Join(candidates, "21.0.1-graal_ce"))
+	require.NoError(t, os.Symlink(filepath.Join(candidates, "17.0.8-tem"), filepath.Join(candidates, "current")))
+
+	tool, ok := GetTool("sdkman")
+	require.True(t, ok)
+
+	installs, skipped, err := tool.Scan(root)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+	require.Len(t, installs, 2)
+	assert.Equal(t, "17.0.8", installs[0].Version.String())
+	assert.Equal(t, "tem", installs[0].Name)
+	assert.Equal(t, "graal-ce", installs[1].Name)
+	assert.Equal(t, "17.0.8-tem", tool.DefaultVersion(root))
+}
+
+func TestDefaultVersion_Pyenv(t *testing.T) {
+	root := t.TempDir()
+	tool, ok := GetTool("pyenv")
+	require.True(t, ok)
+	assert.Equal(t, "", tool.DefaultVersion(root))
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "version"), []byte("3.11.4 2.7.18\n"), 0644))
+	assert.Equal(t, "3.11.4", tool.DefaultVersion(root))
+}
+
+func TestScanRcFiles(t *testing.T) {
+	home := t.TempDir()
+	rc := "export PATH=$HOME/bin:$PATH\n" +
+		"export PYENV_ROOT=\"$HOME/.pyenv\"\n" +
+		"eval \"$(pyenv init -)\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".zshrc"), []byte(rc), 0644))
+
+	tool, ok := GetTool("pyenv")
+	require.True(t, ok)
+	lines := tool.ScanRcFiles(home)
+	require.Len(t, lines, 2)
+	assert.Equal(t, 2, lines[0].Line)
+	assert.Equal(t, `eval "$(pyenv init -)"`, lines[1].Text)
+}
+
+func TestScan_Rbenv(t *testing.T) {
+	root := t.TempDir()
+	versions := filepath.Join(root, "versions")
+	mkdirs(t, filepath.Join(versions, "3.2.2"), filepath.Join(versions, "3.3.0"))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "version"), []byte("3.3.0\n"), 0644))
+
+	tool, ok := GetTool("rbenv")
+	require.True(t, ok)
+	assert.Equal(t, "ruby", tool.Lang)
+
+	installs, skipped, err := tool.Scan(root)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+	require.Len(t, installs, 2)
+	assert.Equal(t, "3.2.2", installs[0].Version.String())
+	assert.Equal(t, "3.3.0", tool.DefaultVersion(root))
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ToolVersionsFile 为 asdf 风格的多语言版本文件
+const ToolVersionsFile = ".tool-versions"
+
+// pinFiles 为只包含单个版本号的项目文件与语言的对应关系
+var pinFiles = map[string]string{
+	".go-version":     "go",
+	".nvmrc":          "node",
+	".node-version":   "node",
+	".python-version": "python",
+	".java-version":   "java",
+}
+
+// toolAliases 为 .tool-versions 中的插件名与 gvm 语言名称的对应关系
+var toolAliases = map[string]string{
+	"golang": "go",
+	"nodejs": "node",
+}
+
+// skipDirs 为扫描时跳过的目录
+var skipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// Pin 为项目文件中固定的语言版本
+type Pin struct {
+	Lang    string
+	Version string
+	File    string
+}
+
+// ParseFile 解析单个项目文件，不是项目文件或无法读取时返回空
+func ParseFile(file string) []Pin {
+	name := filepath.Base(file)
+	lang, single := pinFiles[name]
+	if !single && name != ToolVersionsFile {
+		return nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+
+	res := make([]Pin, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if single {
+			// 单版本文件只取第一行有效内容
+			return append(res, Pin{Lang: lang, Version: fields[0], File: file})
+		}
+		if len(fields) < 2 {
+			continue
+		}
+		tool := fields[0]
+		if alias, ok := toolAliases[tool]; ok {
+			tool = alias
+		}
+		// .tool-versions 允许为同一语言列出多个候选版本
+		for _, v := range fields[1:] {
+			res = append(res, Pin{Lang: tool, Version: v, File: file})
+		}
+	}
+	return res
+}
+
+// Scan 递归扫描目录中的项目文件，返回其中固定的所有版本
+func Scan(dirs []string) ([]Pin, error) {
+	res := make([]Pin, 0)
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p != dir && skipDirs[d.Name()] {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			res = append(res, ParseFile(p)...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
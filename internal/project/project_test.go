@@ -0,0 +1,56 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, file, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "api", ".go-version"), "1.21.3\n")
+	writeFile(t, filepath.Join(root, "web", ".nvmrc"), "v18\n")
+	writeFile(t, filepath.Join(root, "tools", ToolVersionsFile), "# comment\ngolang 1.20.1\nnodejs 20.5.0 18.17.0\npython\n")
+	writeFile(t, filepath.Join(root, "web", "node_modules", "dep", ".nvmrc"), "12\n")
+	writeFile(t, filepath.Join(root, "README.md"), "1.0.0\n")
+
+	pins, err := Scan([]string{root})
+	require.NoError(t, err)
+
+	got := make(map[string][]string)
+	for _, p := range pins {
+		got[p.Lang] = append(got[p.Lang], p.Version)
+	}
+	assert.Equal(t, map[string][]string{
+		"go":   {"1.21.3", "1.20.1"},
+		"node": {"20.5.0", "18.17.0", "v18"},
+	}, got)
+}
+
+func TestScan_MissingDir(t *testing.T) {
+	_, err := Scan([]string{filepath.Join(t.TempDir(), "missing")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testutil 各个包的测试共用的辅助函数
+package testutil
+
+import (
+	"testing"
+
+	"github.com/toodofun/gvm/internal/core"
+)
+
+// SetRootDir 将 gvm 根目录替换为临时目录并返回，测试结束时恢复
+func SetRootDir(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	original := core.GetRootDir
+	t.Cleanup(func() { core.GetRootDir = original })
+	core.GetRootDir = func() string { return root }
+	return root
+}
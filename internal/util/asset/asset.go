@@ -0,0 +1,342 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asset
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/toodofun/gvm/internal/core"
+
+	"github.com/duke-git/lancet/v2/slice"
+)
+
+// FormatRaw 不是压缩包的可执行文件，如 jq、yq 发布的单个二进制文件
+const FormatRaw = "raw"
+
+var (
+	// defaultOS 系统名称在文件名中的常见写法
+	defaultOS = map[string][]string{
+		"linux":   {"linux"},
+		"darwin":  {"darwin", "macos", "osx", "apple", "mac"},
+		"windows": {"windows", "win", "win64", "win32"},
+		"freebsd": {"freebsd"},
+	}
+	// defaultArch 架构名称在文件名中的常见写法
+	defaultArch = map[string][]string{
+		"amd64": {"amd64", "x86_64", "x64", "64bit"},
+		"arm64": {"arm64", "aarch64"},
+		"386":   {"386", "i386", "i686", "x86", "32bit"},
+		"arm":   {"armv7", "armv6", "armhf", "arm"},
+	}
+	// defaultFormats 支持的格式，靠前的优先，raw 表示不是压缩包的可执行文件
+	defaultFormats = []string{".tar.gz", ".tgz", ".tar.xz", ".txz", ".tar.bz2", ".tbz2", ".zip", FormatRaw}
+	// extension 文件扩展名，x86_64、1.2.0 这类结尾不是扩展名
+	extension = regexp.MustCompile(`\.[a-z][a-z0-9]{0,5}$`)
+	// defaultExclude 校验文件、签名、SBOM 和系统安装包，文件名即使包含系统和架构也不会选择
+	defaultExclude = []string{
+		".sha256", ".sha512", ".sha1", ".md5", "checksums", ".sig", ".asc", ".pem", ".sbom", ".spdx",
+		".json", ".txt", ".deb", ".rpm", ".apk", ".msi", ".dmg", ".pkg",
+	}
+)
+
+// Rules 安装包选择规则，可以通过数据源的查询参数配置，如 owner/repo?asset=tool-{os}-{arch}.tar.gz：
+//   - asset：文件名模板，支持 {version}、{os}、{arch}
+//   - regex：文件名正则，同样支持上述占位符
+//   - os.<GOOS>、arch.<GOARCH>：追加系统、架构的别名，多个用逗号分隔，如 arch.amd64=x86_64
+//   - formats：优先使用的压缩格式，如 tar.xz,zip
+//   - exclude：追加排除的文件名片段
+//   - strip、bin：安装后的目录结构，见 Layout
+type Rules struct {
+	Template string
+	Pattern  string
+	OS       map[string][]string
+	Arch     map[string][]string
+	Formats  []string
+	Exclude  []string
+	Layout   Layout
+}
+
+// DefaultRules 返回默认规则
+func DefaultRules() *Rules {
+	r := &Rules{
+		OS:      make(map[string][]string),
+		Arch:    make(map[string][]string),
+		Formats: append([]string(nil), defaultFormats...),
+		Exclude: append([]string(nil), defaultExclude...),
+	}
+	for k, v := range defaultOS {
+		r.OS[k] = append([]string(nil), v...)
+	}
+	for k, v := range defaultArch {
+		r.Arch[k] = append([]string(nil), v...)
+	}
+	return r
+}
+
+// ParseRules 解析数据源中 ? 之后的规则，值中的 + 不会被当作空格，需要时可以使用 % 转义
+func ParseRules(query string) (*Rules, error) {
+	r := DefaultRules()
+	if query == "" {
+		return r, nil
+	}
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		value, err := url.PathUnescape(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset rule %s: %w", pair, err)
+		}
+		switch {
+		case key == "asset":
+			r.Template = value
+		case key == "regex":
+			if _, err := regexp.Compile(expand(value, "", "", "")); err != nil {
+				return nil, fmt.Errorf("invalid asset regex %s: %w", value, err)
+			}
+			r.Pattern = value
+		case key == "formats":
+			r.Formats = nil
+			for _, f := range splitList(value) {
+				if f != FormatRaw {
+					f = "." + strings.TrimPrefix(f, ".")
+				}
+				r.Formats = append(r.Formats, f)
+			}
+		case key == "strip":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid strip %s, expected a non-negative number", value)
+			}
+			r.Layout.Strip = n
+		case key == "bin":
+			for _, b := range strings.Split(value, ",") {
+				if b = strings.TrimSpace(b); b == "" {
+					continue
+				}
+				p, name, _ := strings.Cut(b, ":")
+				r.Layout.Bins = append(r.Layout.Bins, Bin{Path: p, Name: name})
+			}
+		case key == "exclude":
+			r.Exclude = append(r.Exclude, splitList(value)...)
+		case strings.HasPrefix(key, "os."):
+			addAliases(r.OS, strings.TrimPrefix(key, "os."), splitList(value))
+		case strings.HasPrefix(key, "arch."):
+			addAliases(r.Arch, strings.TrimPrefix(key, "arch."), splitList(value))
+		default:
+			return nil, fmt.Errorf("unknown asset rule %s, supported: asset, regex, formats, exclude, os.<os>, arch.<arch>, strip, bin", key)
+		}
+	}
+	return r, nil
+}
+
+// addAliases 为 key 追加别名，同一别名原先属于其他系统或架构时会被移除
+func addAliases(table map[string][]string, key string, aliases []string) {
+	for k, v := range table {
+		if k == key {
+			continue
+		}
+		kept := make([]string, 0, len(v))
+		for _, a := range v {
+			if !slice.Contain(aliases, a) {
+				kept = append(kept, a)
+			}
+		}
+		table[k] = kept
+	}
+	table[key] = append(table[key], aliases...)
+}
+
+func splitList(value string) []string {
+	res := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// expand 替换模板中的占位符
+func expand(template, version, goos, goarch string) string {
+	return strings.NewReplacer("{version}", version, "{os}", goos, "{arch}", goarch).Replace(template)
+}
+
+// alternation 将别名转换为正则中的分组
+func alternation(aliases []string) string {
+	quoted := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		quoted = append(quoted, regexp.QuoteMeta(a))
+	}
+	return "(?:" + strings.Join(quoted, "|") + ")"
+}
+
+// detect 返回文件名中出现的最长别名对应的键，别名两侧必须是非字母数字字符，避免 x86 匹配到 x86_64
+func detect(name string, table map[string][]string) string {
+	type entry struct{ key, alias string }
+	entries := make([]entry, 0)
+	for k, aliases := range table {
+		for _, a := range aliases {
+			entries = append(entries, entry{k, a})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if len(entries[i].alias) != len(entries[j].alias) {
+			return len(entries[i].alias) > len(entries[j].alias)
+		}
+		return entries[i].key < entries[j].key
+	})
+	for _, e := range entries {
+		if containsWord(name, e.alias) {
+			return e.key
+		}
+	}
+	return ""
+}
+
+func containsWord(s, word string) bool {
+	isAlnum := func(c byte) bool {
+		return c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+	}
+	for i := 0; ; {
+		j := strings.Index(s[i:], word)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(word)
+		if (start == 0 || !isAlnum(s[start-1])) && (end == len(s) || !isAlnum(s[end])) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+// format 返回文件的格式，不在 Formats 中时为空
+func (r *Rules) format(name string) string {
+	for _, f := range r.Formats {
+		if f == FormatRaw && IsRaw(name) || strings.HasSuffix(name, f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// IsRaw 判断安装包是否为可执行文件而不是压缩包
+func IsRaw(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.HasSuffix(lower, ".exe") || !extension.MatchString(lower)
+}
+
+func (r *Rules) aliases(table map[string][]string, key string) []string {
+	if aliases := table[key]; len(aliases) > 0 {
+		return aliases
+	}
+	return []string{key}
+}
+
+func (r *Rules) matchTemplate(name, version, goos, goarch string) bool {
+	for _, v := range []string{version, strings.TrimPrefix(version, "v")} {
+		for _, o := range r.aliases(r.OS, goos) {
+			for _, a := range r.aliases(r.Arch, goarch) {
+				if strings.EqualFold(expand(r.Template, v, o, a), name) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// check 检查单个安装包，返回不满足规则的原因
+func (r *Rules) check(name, version, goos, goarch string) string {
+	lower := strings.ToLower(name)
+	for _, e := range r.Exclude {
+		if strings.Contains(lower, strings.ToLower(e)) {
+			return fmt.Sprintf("excluded by %q", e)
+		}
+	}
+
+	switch {
+	case r.Template != "":
+		if !r.matchTemplate(name, version, goos, goarch) {
+			return fmt.Sprintf("does not match template %s", r.Template)
+		}
+	case r.Pattern != "":
+		pattern := expand(r.Pattern, regexp.QuoteMeta(strings.TrimPrefix(version, "v")),
+			alternation(r.aliases(r.OS, goos)), alternation(r.aliases(r.Arch, goarch)))
+		if !regexp.MustCompile("(?i)" + pattern).MatchString(name) {
+			return fmt.Sprintf("does not match regex %s", r.Pattern)
+		}
+	default:
+		switch o := detect(lower, r.OS); o {
+		case goos:
+		case "":
+			return fmt.Sprintf("no OS in name, expected one of %s", strings.Join(r.aliases(r.OS, goos), ", "))
+		default:
+			return fmt.Sprintf("built for %s", o)
+		}
+		switch a := detect(lower, r.Arch); a {
+		case goarch:
+		case "":
+			return fmt.Sprintf("no architecture in name, expected one of %s", strings.Join(r.aliases(r.Arch, goarch), ", "))
+		default:
+			return fmt.Sprintf("built for %s", a)
+		}
+	}
+
+	if r.format(lower) == "" {
+		return fmt.Sprintf("unsupported format, expected one of %s", strings.Join(r.Formats, ", "))
+	}
+	return ""
+}
+
+// Select 从安装包文件名中选择适合当前平台的一个，同时返回其余安装包未被选择的原因；
+// 都不满足时返回空字符串。多个安装包满足规则时优先非 musl 版本，其次按 Formats 的顺序
+func (r *Rules) Select(names []string, version, goos, goarch string) (string, []core.AssetRejection) {
+	rejections := make([]core.AssetRejection, 0)
+	candidates := make([]string, 0)
+	for _, name := range names {
+		if reason := r.check(name, version, goos, goarch); reason != "" {
+			rejections = append(rejections, core.AssetRejection{Name: name, Reason: reason})
+			continue
+		}
+		candidates = append(candidates, name)
+	}
+	if len(candidates) == 0 {
+		return "", rejections
+	}
+
+	rank := func(name string) int {
+		lower := strings.ToLower(name)
+		res := slice.IndexOf(r.Formats, r.format(lower))
+		if containsWord(lower, "musl") {
+			res += len(r.Formats) + 1
+		}
+		return res
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return rank(candidates[i]) < rank(candidates[j])
+	})
+	for _, name := range candidates[1:] {
+		rejections = append(rejections, core.AssetRejection{Name: name, Reason: fmt.Sprintf("%s is preferred", candidates[0])})
+	}
+	return candidates[0], rejections
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+)
+
+var names = []string{
+	"tool-1.2.0-x86_64-unknown-linux-musl.tar.gz",
+	"tool-1.2.0-x86_64-unknown-linux-gnu.tar.gz",
+	"tool-1.2.0-x86_64-unknown-linux-gnu.tar.gz.sha256",
+	"tool-1.2.0-i686-unknown-linux-gnu.tar.gz",
+	"tool-1.2.0-aarch64-apple-darwin.tar.gz",
+	"tool-1.2.0-macos-arm64.tar.xz",
+	"tool-1.2.0-x86_64-pc-windows-msvc.zip",
+	"tool-1.2.0.sbom",
+	"tool_linux_amd64.deb",
+}
+
+func TestSelect(t *testing.T) {
+	rules := DefaultRules()
+
+	name, rejected := rules.Select(names, "v1.2.0", "linux", "amd64")
+	assert.Equal(t, "tool-1.2.0-x86_64-unknown-linux-gnu.tar.gz", name)
+	assert.Contains(t, rejected, core.AssetRejection{
+		Name:   "tool-1.2.0-x86_64-unknown-linux-musl.tar.gz",
+		Reason: "tool-1.2.0-x86_64-unknown-linux-gnu.tar.gz is preferred",
+	})
+	assert.Contains(t, rejected, core.AssetRejection{
+		Name:   "tool-1.2.0-x86_64-unknown-linux-gnu.tar.gz.sha256",
+		Reason: `excluded by ".sha256"`,
+	})
+	assert.Contains(t, rejected, core.AssetRejection{Name: "tool-1.2.0-i686-unknown-linux-gnu.tar.gz", Reason: "built for 386"})
+	assert.Contains(t, rejected, core.AssetRejection{Name: "tool-1.2.0-aarch64-apple-darwin.tar.gz", Reason: "built for darwin"})
+	assert.Len(t, rejected, len(names)-1)
+
+	name, _ = rules.Select(names, "v1.2.0", "darwin", "arm64")
+	assert.Equal(t, "tool-1.2.0-aarch64-apple-darwin.tar.gz", name)
+
+	name, _ = rules.Select(names, "v1.2.0", "linux", "386")
+	assert.Equal(t, "tool-1.2.0-i686-unknown-linux-gnu.tar.gz", name)
+
+	name, rejected = rules.Select(names, "v1.2.0", "linux", "arm64")
+	assert.Empty(t, name)
+	assert.Len(t, rejected, len(names))
+}
+
+func TestSelectRaw(t *testing.T) {
+	raw := []string{"jq-linux-amd64", "jq-macos-arm64", "jq-windows-amd64.exe", "jq-1.7.1.tar.gz", "sha256sum.txt"}
+	rules := DefaultRules()
+
+	name, _ := rules.Select(raw, "jq-1.7.1", "linux", "amd64")
+	assert.Equal(t, "jq-linux-amd64", name)
+	name, _ = rules.Select(raw, "jq-1.7.1", "windows", "amd64")
+	assert.Equal(t, "jq-windows-amd64.exe", name)
+
+	// 同时提供压缩包和可执行文件时优先压缩包
+	name, _ = rules.Select([]string{"yq_linux_amd64", "yq_linux_amd64.tar.gz"}, "v4.44.1", "linux", "amd64")
+	assert.Equal(t, "yq_linux_amd64.tar.gz", name)
+
+	rules, err := ParseRules("formats=raw")
+	require.NoError(t, err)
+	name, rejected := rules.Select([]string{"yq_linux_amd64", "yq_linux_amd64.tar.gz"}, "v4.44.1", "linux", "amd64")
+	assert.Equal(t, "yq_linux_amd64", name)
+	assert.Equal(t, "unsupported format, expected one of raw", rejected[0].Reason)
+}
+
+func TestSelectWithRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		goos   string
+		goarch string
+		want   string
+	}{
+		{
+			name:   "template",
+			query:  "asset=tool-{version}-{arch}-unknown-{os}-musl.tar.gz",
+			goos:   "linux",
+			goarch: "amd64",
+			want:   "tool-1.2.0-x86_64-unknown-linux-musl.tar.gz",
+		},
+		{
+			name:   "formats",
+			query:  "formats=tar.xz,tar.gz",
+			goos:   "darwin",
+			goarch: "arm64",
+			want:   "tool-1.2.0-macos-arm64.tar.xz",
+		},
+		{
+			name:   "regex",
+			query:  "regex=^tool-{version}-{arch}-.*-{os}-msvc%2Ezip$",
+			goos:   "windows",
+			goarch: "amd64",
+			want:   "tool-1.2.0-x86_64-pc-windows-msvc.zip",
+		},
+		{
+			name:   "exclude",
+			query:  "exclude=gnu",
+			goos:   "linux",
+			goarch: "amd64",
+			want:   "tool-1.2.0-x86_64-unknown-linux-musl.tar.gz",
+		},
+		{
+			name:   "arch alias",
+			query:  "arch.arm64=i686",
+			goos:   "linux",
+			goarch: "arm64",
+			want:   "tool-1.2.0-i686-unknown-linux-gnu.tar.gz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules(tt.query)
+			require.NoError(t, err)
+			name, _ := rules.Select(names, "v1.2.0", tt.goos, tt.goarch)
+			assert.Equal(t, tt.want, name)
+		})
+	}
+}
+
+func TestParseRulesError(t *testing.T) {
+	_, err := ParseRules("pattern=tool")
+	assert.ErrorContains(t, err, "unknown asset rule pattern")
+	_, err = ParseRules("regex=tool-(")
+	assert.ErrorContains(t, err, "invalid asset regex")
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/toodofun/gvm/internal/util/compress"
+)
+
+// extractDir 解压时使用的临时目录，位于版本目录下，去掉顶层目录后移动到版本目录
+const extractDir = ".gvm-extract"
+
+// Bin 需要放到 bin 目录的可执行文件，Path 为相对版本目录的路径（支持通配符），Name 为空时使用文件名
+type Bin struct {
+	Path string
+	Name string
+}
+
+// Layout 安装后的目录结构，可以通过数据源的查询参数配置：
+//   - strip=1：去掉压缩包中的顶层目录
+//   - bin=path[:name]：将压缩包中的可执行文件链接到 bin 目录，多个用逗号分隔，如 bin=*/kubectl；
+//     安装包不是压缩包时用于指定可执行文件的名称，如 bin=jq
+type Layout struct {
+	Strip int
+	Bins  []Bin
+}
+
+// Install 将下载的安装包安装到 dest：压缩包解压后按 Strip 去掉顶层目录并链接 Bins，
+// 可执行文件复制为 dest/bin/<name> 并添加执行权限，name 默认为 defaultName
+func (l *Layout) Install(ctx context.Context, file, dest, defaultName string) error {
+	if IsRaw(filepath.Base(file)) {
+		name := defaultName
+		if len(l.Bins) > 0 {
+			name = l.Bins[0].Name
+			if name == "" {
+				name = filepath.Base(l.Bins[0].Path)
+			}
+		}
+		if runtime.GOOS == "windows" && !strings.HasSuffix(strings.ToLower(name), ".exe") {
+			name += ".exe"
+		}
+		return copyExecutable(file, filepath.Join(dest, "bin", name))
+	}
+
+	staging := filepath.Join(dest, extractDir)
+	if err := os.RemoveAll(staging); err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+	if err := compress.Extract(ctx, file, staging); err != nil {
+		return err
+	}
+	root := staging
+	for i := 0; i < l.Strip; i++ {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			return err
+		}
+		if len(entries) != 1 || !entries[0].IsDir() {
+			return fmt.Errorf("can not strip %d directories, %s does not contain a single top-level directory",
+				l.Strip, filepath.Base(file))
+		}
+		root = filepath.Join(root, entries[0].Name())
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := os.Rename(filepath.Join(root, e.Name()), filepath.Join(dest, e.Name())); err != nil {
+			return fmt.Errorf("failed to move %s: %w", e.Name(), err)
+		}
+	}
+	return l.linkBins(dest)
+}
+
+// linkBins 在 dest/bin 中创建指向 Bins 的相对链接
+func (l *Layout) linkBins(dest string) error {
+	binDir := filepath.Join(dest, "bin")
+	for _, b := range l.Bins {
+		matches, err := filepath.Glob(filepath.Join(dest, b.Path))
+		if err != nil {
+			return fmt.Errorf("invalid binary path %s: %w", b.Path, err)
+		}
+		if len(matches) != 1 {
+			return fmt.Errorf("binary path %s matches %d files, expected exactly one", b.Path, len(matches))
+		}
+		target := matches[0]
+		name := b.Name
+		if name == "" {
+			name = filepath.Base(target)
+		}
+		if err := os.Chmod(target, 0755); err != nil {
+			return err
+		}
+		link := filepath.Join(binDir, name)
+		if link == target {
+			continue
+		}
+		if err := os.MkdirAll(binDir, 0755); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(binDir, target)
+		if err != nil {
+			return err
+		}
+		_ = os.Remove(link)
+		if err := os.Symlink(rel, link); err != nil {
+			return fmt.Errorf("failed to link %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func copyExecutable(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asset
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createZip(t *testing.T, filename string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(filename)
+	require.NoError(t, err)
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+}
+
+func TestLayoutInstallRaw(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executable names differ on windows")
+	}
+	dir := t.TempDir()
+	file := filepath.Join(dir, "jq-linux-amd64")
+	require.NoError(t, os.WriteFile(file, []byte("#!/bin/sh\n"), 0644))
+	dest := filepath.Join(dir, "1.7.1")
+
+	require.NoError(t, (&Layout{}).Install(context.Background(), file, dest, "jq"))
+	info, err := os.Stat(filepath.Join(dest, "bin", "jq"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	rules, err := ParseRules("bin=jq17")
+	require.NoError(t, err)
+	require.NoError(t, rules.Layout.Install(context.Background(), file, dest, "jq"))
+	assert.FileExists(t, filepath.Join(dest, "bin", "jq17"))
+}
+
+func TestLayoutInstallArchive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+	dir := t.TempDir()
+	file := filepath.Join(dir, "tool.zip")
+	createZip(t, file, map[string]string{
+		"tool-1.2.0/tool":      "binary",
+		"tool-1.2.0/README.md": "readme",
+	})
+	dest := filepath.Join(dir, "1.2.0")
+
+	rules, err := ParseRules("strip=1&bin=tool:tl")
+	require.NoError(t, err)
+	require.NoError(t, rules.Layout.Install(context.Background(), file, dest, "tool"))
+	assert.FileExists(t, filepath.Join(dest, "README.md"))
+	target, err := os.Readlink(filepath.Join(dest, "bin", "tl"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("..", "tool"), target)
+	assert.NoDirExists(t, filepath.Join(dest, extractDir))
+
+	rules, err = ParseRules("strip=2")
+	require.NoError(t, err)
+	err = rules.Layout.Install(context.Background(), file, filepath.Join(dir, "other"), "tool")
+	assert.ErrorContains(t, err, "does not contain a single top-level directory")
+
+	rules, err = ParseRules("bin=*/missing")
+	require.NoError(t, err)
+	err = rules.Layout.Install(context.Background(), file, filepath.Join(dir, "missing"), "tool")
+	assert.ErrorContains(t, err, "matches 0 files")
+}
@@ -17,9 +17,10 @@ package color
 import "github.com/fatih/color"
 
 var (
-	fgRed   = color.New(color.FgRed).SprintFunc()
-	fgGreen = color.New(color.FgGreen).SprintFunc()
-	fgBlue  = color.New(color.FgBlue).SprintFunc()
+	fgRed    = color.New(color.FgRed).SprintFunc()
+	fgGreen  = color.New(color.FgGreen).SprintFunc()
+	fgBlue   = color.New(color.FgBlue).SprintFunc()
+	fgYellow = color.New(color.FgYellow).SprintFunc()
 )
 
 func RedFont(s string) string {
@@ -33,3 +34,7 @@ func GreenFont(s string) string {
 func BlueFont(s string) string {
 	return fgBlue(s)
 }
+
+func YellowFont(s string) string {
+	return fgYellow(s)
+}
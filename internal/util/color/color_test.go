@@ -47,3 +47,10 @@ func TestBlueFont(t *testing.T) {
 		t.Errorf("expected blue ANSI code in output, got: %q", result)
 	}
 }
+
+func TestYellowFont(t *testing.T) {
+	result := mycolor.YellowFont("warn")
+	if !strings.Contains(result, "\x1b[33m") {
+		t.Errorf("expected yellow ANSI code in output, got: %q", result)
+	}
+}
@@ -17,6 +17,7 @@ package compress
 import (
 	"archive/tar"
 	"archive/zip"
+	"compress/bzip2"
 	"compress/gzip"
 	"context"
 	"errors"
@@ -49,8 +50,27 @@ func UnTarGz(ctx context.Context, tarGzName string, dest string) error {
 	if err != nil {
 		return err
 	}
+	return untar(ctx, unGzStream, dest)
+}
 
-	tarStream := tar.NewReader(unGzStream)
+// UnTarBz2 解压 .tar.bz2 文件
+func UnTarBz2(ctx context.Context, tarBz2Name string, dest string) error {
+	f, err := os.Open(tarBz2Name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.GetLogger(ctx).Warnf("Close body error: %s", err)
+		}
+	}()
+	return untar(ctx, bzip2.NewReader(f), dest)
+}
+
+// untar 将 tar 流解压到 dest，拒绝指向 dest 之外的路径
+func untar(ctx context.Context, r io.Reader, dest string) error {
+	logger := log.GetLogger(ctx)
+	tarStream := tar.NewReader(r)
 	absPath, err := filepath.Abs(dest)
 	if err != nil {
 		return err
@@ -259,3 +279,22 @@ func UnTarXz(ctx context.Context, tarXzName string, dest string) error {
 
 	return nil
 }
+
+// Extract 根据文件后缀选择对应的解压方式
+func Extract(ctx context.Context, file string, dest string) error {
+	name := strings.ToLower(file)
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return UnTarGz(ctx, file, dest)
+	case strings.HasSuffix(name, ".tar.xz"), strings.HasSuffix(name, ".txz"):
+		return UnTarXz(ctx, file, dest)
+	case strings.HasSuffix(name, ".tar.bz2"), strings.HasSuffix(name, ".tbz2"), strings.HasSuffix(name, ".tbz"):
+		return UnTarBz2(ctx, file, dest)
+	case strings.HasSuffix(name, ".zip"):
+		return UnZip(ctx, file, dest)
+	case strings.HasSuffix(name, ".pkg"):
+		return UnPkg(file, dest)
+	default:
+		return fmt.Errorf("unsupported archive format: %s", filepath.Base(file))
+	}
+}
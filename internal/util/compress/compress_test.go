@@ -20,6 +20,7 @@ import (
 	"compress/gzip"
 	"context"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 )
@@ -189,3 +190,46 @@ func TestUnTarXz(t *testing.T) {
 		}
 	})
 }
+
+func TestUnTarBz2(t *testing.T) {
+	if _, err := exec.LookPath("bzip2"); err != nil {
+		t.Skip("bzip2 not found")
+	}
+	tmpDir := t.TempDir()
+	tgzPath := filepath.Join(tmpDir, "test.tar.gz")
+	createTestTarGz(t, tgzPath, map[string]string{"dir/foo.txt": "hello bz2"})
+
+	// 将 tar.gz 转换为 tar.bz2，标准库只提供 bzip2 的解压
+	tarPath := filepath.Join(tmpDir, "test.tar")
+	if out, err := exec.Command("sh", "-c", "gzip -dc "+tgzPath+" > "+tarPath+" && bzip2 "+tarPath).CombinedOutput(); err != nil {
+		t.Fatalf("create tar.bz2 failed: %v %s", err, out)
+	}
+
+	dest := filepath.Join(tmpDir, "extracted")
+	if err := Extract(context.Background(), tarPath+".bz2", dest); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dest, "dir", "foo.txt"))
+	if err != nil || string(data) != "hello bz2" {
+		t.Errorf("expected %q, got %q (err: %v)", "hello bz2", string(data), err)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tmpDir := t.TempDir()
+	tgzPath := filepath.Join(tmpDir, "test.tgz")
+	createTestTarGz(t, tgzPath, map[string]string{"foo.txt": "hello tgz"})
+
+	dest := filepath.Join(tmpDir, "extracted")
+	if err := Extract(context.Background(), tgzPath, dest); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dest, "foo.txt"))
+	if err != nil || string(data) != "hello tgz" {
+		t.Errorf("expected %q, got %q (err: %v)", "hello tgz", string(data), err)
+	}
+
+	if err := Extract(context.Background(), filepath.Join(tmpDir, "test.rar"), dest); err == nil {
+		t.Error("expected error for unsupported archive format")
+	}
+}
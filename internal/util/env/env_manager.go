@@ -114,8 +114,9 @@ func (m *Manager) RemoveEnv(key, value string) error {
 	}
 
 	valueList := strings.Split(values, pathSeparator)
+	// 只剩 $KEY 自身引用时视为已清空
 	newValueList := slice.Filter(valueList, func(index int, item string) bool {
-		return item != value
+		return item != value && item != "$"+key
 	})
 
 	if len(newValueList) == 0 {
@@ -66,7 +66,7 @@ func (m *Manager) setGvmEnv(key, value string) error {
 
 	var newFileContent []string
 	found := false
-	lines := strings.Split(string(data), "\n")
+	lines := splitLines(data)
 	for _, line := range lines {
 		l := strings.TrimPrefix(line, "export ")
 		if strings.HasPrefix(l, key+"=") {
@@ -127,7 +127,7 @@ func (m *Manager) deleteGvmEnv(key string) error {
 	}
 
 	var newFileContent []string
-	lines := strings.Split(string(data), "\n")
+	lines := splitLines(data)
 	for _, line := range lines {
 		l := strings.TrimPrefix(line, "export ")
 		if !strings.HasPrefix(l, key+"=") {
@@ -265,3 +265,12 @@ func (m *Manager) appendToConfigFile() error {
 	_, err = file.WriteString(strings.Join(newLines, "\n") + "\n")
 	return err
 }
+
+// splitLines 按行拆分文件内容，忽略末尾换行，避免每次写回都多出一个空行
+func splitLines(data []byte) []string {
+	content := strings.TrimRight(string(data), "\n")
+	if len(content) == 0 {
+		return nil
+	}
+	return strings.Split(content, "\n")
+}
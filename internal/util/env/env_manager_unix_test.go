@@ -0,0 +1,54 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_AppendRemoveEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("SHELL", "/bin/bash")
+	m := NewEnvManager()
+
+	require.NoError(t, m.AppendEnv("PATH", "/opt/a/bin"))
+	require.NoError(t, m.SetEnv("GOROOT", "/opt/a"))
+	require.NoError(t, m.AppendEnv("PATH", "/opt/b/bin"))
+
+	v, err := m.GetEnv("PATH")
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/b/bin:/opt/a/bin:$PATH", v)
+
+	require.NoError(t, m.RemoveEnv("PATH", "/opt/a/bin"))
+	v, err = m.GetEnv("PATH")
+	require.NoError(t, err)
+	assert.Equal(t, "/opt/b/bin:$PATH", v)
+
+	require.NoError(t, m.RemoveEnv("PATH", "/opt/b/bin"))
+	require.NoError(t, m.DeleteEnv("GOROOT"))
+	require.NoError(t, m.DeleteEnv("GOROOT"))
+
+	data, err := os.ReadFile(filepath.Join(home, defaultEnvFile))
+	require.NoError(t, err)
+	assert.Equal(t, "\n", string(data), "removing every entry should leave an empty file")
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eol
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/file"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+const (
+	cacheFile = ".eol.json"
+	// WarningDays 距离 EOL 不足该天数时开始提示
+	WarningDays = 180
+)
+
+// cadences 为按固定周期发布新版本线的语言及其发布间隔（月）。Go 每六个月发布一个版本线，
+// 只维护最新的两个，数据之后的版本线按该周期推算 EOL，避免快照过期后新版本线没有提示
+var cadences = map[string]int{"go": 6}
+
+// snapshot 为随程序发布的生命周期数据，在没有执行过 gvm eol --refresh 时使用
+//
+//go:embed snapshot.json
+var snapshot []byte
+
+// GetPath 返回刷新后的生命周期数据的缓存路径
+func GetPath() string {
+	return filepath.Join(core.GetRootDir(), cacheFile)
+}
+
+func loadFile(data []byte) (map[string][]*core.Lifecycle, error) {
+	res := make(map[string][]*core.Lifecycle)
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Load 返回语言的生命周期数据，优先使用刷新后的缓存，否则使用内置快照
+func Load(lang string) []*core.Lifecycle {
+	if data, err := os.ReadFile(GetPath()); err == nil {
+		if cached, err := loadFile(data); err == nil {
+			if cycles, ok := cached[lang]; ok {
+				return extrapolate(lang, cycles, time.Now())
+			}
+		}
+	}
+	builtin, err := loadFile(snapshot)
+	if err != nil {
+		return nil
+	}
+	return extrapolate(lang, builtin[lang], time.Now())
+}
+
+// extrapolate 以最新的已知 EOL 为基准，按发布周期补全之后已经发布的版本线，
+// 一个版本线在之后第二个版本线发布时结束维护
+func extrapolate(lang string, cycles []*core.Lifecycle, now time.Time) []*core.Lifecycle {
+	months, ok := cadences[lang]
+	if !ok {
+		return cycles
+	}
+	res := make([]*core.Lifecycle, len(cycles))
+	copy(res, cycles)
+	Sort(res)
+
+	var base *core.Lifecycle
+	index := make(map[string]int, len(res))
+	for i, c := range res {
+		index[c.Cycle] = i
+		if !c.EOL.IsZero() {
+			base = c
+		}
+	}
+	if base == nil {
+		return res
+	}
+	var major, minor int
+	if _, err := fmt.Sscanf(base.Cycle, "%d.%d", &major, &minor); err != nil {
+		return res
+	}
+	for n := 1; ; n++ {
+		end := base.EOL.AddDate(0, months*n, 0)
+		// 版本线的发布时间比 EOL 早两个周期，尚未发布的版本线不补全
+		if end.AddDate(0, -2*months, 0).After(now) {
+			break
+		}
+		cycle := fmt.Sprintf("%d.%d", major, minor+n)
+		if i, ok := index[cycle]; ok {
+			res[i] = &core.Lifecycle{Cycle: cycle, EOL: end}
+			continue
+		}
+		res = append(res, &core.Lifecycle{Cycle: cycle, EOL: end})
+	}
+	return res
+}
+
+// Save 将从上游获取的生命周期数据写入缓存
+func Save(lang string, cycles []*core.Lifecycle) error {
+	cached := make(map[string][]*core.Lifecycle)
+	if data, err := os.ReadFile(GetPath()); err == nil {
+		if c, err := loadFile(data); err == nil {
+			cached = c
+		}
+	}
+	cached[lang] = cycles
+	if err := os.MkdirAll(filepath.Dir(GetPath()), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(GetPath()), err)
+	}
+	return file.WriteJSONFile(GetPath(), cached)
+}
+
+// Find 返回版本所属的版本线，存在多个匹配时取最具体的一个
+func Find(cycles []*core.Lifecycle, version *goversion.Version) *core.Lifecycle {
+	segments := version.Segments()
+	var res *core.Lifecycle
+	resLen := 0
+	for _, c := range cycles {
+		parts := strings.Split(c.Cycle, ".")
+		if len(parts) > len(segments) || len(parts) <= resLen {
+			continue
+		}
+		matched := true
+		for i, p := range parts {
+			n, err := strconv.Atoi(p)
+			if err != nil || n != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			res = c
+			resLen = len(parts)
+		}
+	}
+	return res
+}
+
+// Sort 按版本线从旧到新排序
+func Sort(cycles []*core.Lifecycle) {
+	sort.SliceStable(cycles, func(i, j int) bool {
+		vi, erri := goversion.NewVersion(cycles[i].Cycle)
+		vj, errj := goversion.NewVersion(cycles[j].Cycle)
+		if erri != nil || errj != nil {
+			return cycles[i].Cycle < cycles[j].Cycle
+		}
+		return vi.LessThan(vj)
+	})
+}
+
+// Status 返回版本的生命周期提示：已结束时为 "EOL"，WarningDays 内结束时为 "EOL in N days"，其余情况为空
+func Status(cycles []*core.Lifecycle, version *goversion.Version, now time.Time) string {
+	c := Find(cycles, version)
+	if c == nil || c.EOL.IsZero() {
+		return ""
+	}
+	if !now.Before(c.EOL) {
+		return "EOL"
+	}
+	days := int(math.Ceil(c.EOL.Sub(now).Hours() / 24))
+	if days > WarningDays {
+		return ""
+	}
+	if days == 1 {
+		return "EOL in 1 day"
+	}
+	return fmt.Sprintf("EOL in %d days", days)
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eol
+
+import (
+	"testing"
+	"time"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/testutil"
+)
+
+func TestStatus(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	cycles := []*core.Lifecycle{
+		{Cycle: "3", EOL: now.AddDate(10, 0, 0)},
+		{Cycle: "3.8", EOL: now.AddDate(0, -1, 0)},
+		{Cycle: "3.9", EOL: now.AddDate(0, 0, 30)},
+		{Cycle: "3.10", EOL: now.AddDate(2, 0, 0)},
+		{Cycle: "3.11"},
+	}
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "3.8.18", want: "EOL"},
+		{version: "3.9.1", want: "EOL in 30 days"},
+		{version: "3.10.4", want: ""},
+		{version: "3.11.0", want: ""},
+		{version: "3.12.0", want: ""},
+		{version: "2.7.18", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, Status(cycles, goversion.Must(goversion.NewVersion(tt.version)), now))
+		})
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	testutil.SetRootDir(t)
+
+	builtin := Load("node")
+	require.NotEmpty(t, builtin, "snapshot should contain node release lines")
+	assert.Empty(t, Load("unknown"))
+
+	refreshed := []*core.Lifecycle{{Cycle: "99", EOL: time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC)}}
+	require.NoError(t, Save("node", refreshed))
+	loaded := Load("node")
+	require.Len(t, loaded, 1)
+	assert.Equal(t, "99", loaded[0].Cycle)
+	assert.True(t, refreshed[0].EOL.Equal(loaded[0].EOL))
+	assert.NotEmpty(t, Load("python"), "languages that were not refreshed still use the snapshot")
+}
+
+func TestExtrapolate(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	known := []*core.Lifecycle{
+		{Cycle: "1.22", EOL: date(2025, 2, 11)},
+		{Cycle: "1.23", EOL: date(2025, 8, 12)},
+		{Cycle: "1.24"},
+	}
+
+	cycles := extrapolate("go", known, now)
+	got := make(map[string]time.Time)
+	for _, c := range cycles {
+		got[c.Cycle] = c.EOL
+	}
+	assert.Len(t, cycles, 6, "1.27 was released in 2026-08, 1.28 is not expected before 2027-02")
+	assert.Equal(t, date(2026, 2, 12), got["1.24"])
+	assert.Equal(t, date(2026, 8, 12), got["1.25"])
+	assert.Equal(t, date(2027, 2, 12), got["1.26"])
+	assert.Equal(t, date(2027, 8, 12), got["1.27"])
+	assert.True(t, known[2].EOL.IsZero(), "the loaded data must not be modified")
+
+	assert.Equal(t, "EOL", Status(cycles, goversion.Must(goversion.NewVersion("1.25.3")), now))
+	assert.Equal(t, "EOL in 116 days", Status(cycles, goversion.Must(goversion.NewVersion("1.26.1")), now))
+	assert.Equal(t, known, extrapolate("node", known, now))
+}
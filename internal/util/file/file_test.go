@@ -242,6 +242,64 @@ func TestReadWriteIntegration(t *testing.T) {
 }
 
 // 基准测试
+// 测试CopyDir函数
+func TestCopyDir(t *testing.T) {
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "bin", "tool"), []byte("#!/bin/sh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("tool", filepath.Join(src, "bin", "alias")); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(t.TempDir(), "copy")
+	if err := CopyDir(src, dst); err != nil {
+		t.Fatalf("CopyDir failed: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dst, "bin", "tool"))
+	if err != nil {
+		t.Fatalf("copied file missing: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("expected mode 0755, got %v", info.Mode().Perm())
+	}
+	if link, err := os.Readlink(filepath.Join(dst, "bin", "alias")); err != nil || link != "tool" {
+		t.Errorf("expected symlink to tool, got %q (err: %v)", link, err)
+	}
+
+	if err := CopyDir(filepath.Join(src, "bin", "tool"), dst); err == nil {
+		t.Error("expected error when source is not a directory")
+	}
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bin", "tool"), make([]byte, 100), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README"), make([]byte, 20), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "bin"), filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	size, err := DirSize(dir)
+	if err != nil {
+		t.Fatalf("DirSize failed: %v", err)
+	}
+	if size != 120 {
+		t.Errorf("expected 120 bytes, got %d", size)
+	}
+}
+
 func BenchmarkWriteJSONFile(b *testing.B) {
 	tempDir := b.TempDir()
 	testData := TestConfig{
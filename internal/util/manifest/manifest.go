@@ -0,0 +1,215 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/file"
+)
+
+const (
+	manifestDir = ".manifests"
+)
+
+// cachePatterns 运行时在安装目录中生成的缓存文件，非严格模式下不算作多出的文件
+var cachePatterns = []string{"__pycache__", "*.pyc"}
+
+// Entry 单个文件的校验信息
+type Entry struct {
+	SHA256 string      `json:"sha256,omitempty"`
+	Size   int64       `json:"size"`
+	Mode   fs.FileMode `json:"mode"`
+	Link   string      `json:"link,omitempty"`
+}
+
+// Manifest 安装目录的文件清单，key 为相对安装目录的路径（使用 / 分隔）
+type Manifest struct {
+	Language  string           `json:"language"`
+	Version   string           `json:"version"`
+	CreatedAt time.Time        `json:"createdAt"`
+	Files     map[string]Entry `json:"files"`
+}
+
+// Result 校验结果
+type Result struct {
+	Modified []string
+	Missing  []string
+	Extra    []string
+}
+
+// OK 安装目录与清单完全一致
+func (r *Result) OK() bool {
+	return len(r.Modified) == 0 && len(r.Missing) == 0 && len(r.Extra) == 0
+}
+
+// GetPath 返回指定语言版本的清单文件路径
+func GetPath(lang, version string) string {
+	return filepath.Join(core.GetRootDir(), manifestDir, lang, version+".json")
+}
+
+// Generate 遍历安装目录，记录每个文件的 sha256、大小和权限
+func Generate(lang, version, dir string) (*Manifest, error) {
+	m := &Manifest{
+		Language:  lang,
+		Version:   version,
+		CreatedAt: time.Now(),
+		Files:     make(map[string]Entry),
+	}
+	err := walk(dir, func(rel string, entry Entry) {
+		m.Files[rel] = entry
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Save 保存清单
+func Save(m *Manifest) error {
+	target := GetPath(m.Language, m.Version)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(target), err)
+	}
+	return file.WriteJSONFile(target, m)
+}
+
+// Load 读取清单，清单不存在时返回 os.ErrNotExist
+func Load(lang, version string) (*Manifest, error) {
+	target := GetPath(lang, version)
+	if _, err := os.Stat(target); err != nil {
+		return nil, err
+	}
+	m := new(Manifest)
+	if err := file.ReadJSONFile(target, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Remove 删除清单，清单不存在时不报错
+func Remove(lang, version string) error {
+	if err := os.Remove(GetPath(lang, version)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Verify 将安装目录与清单进行比对，strict 为 false 时忽略 __pycache__、*.pyc 这类运行时生成的缓存文件
+func Verify(m *Manifest, dir string, strict bool) (*Result, error) {
+	res := &Result{}
+	seen := make(map[string]bool, len(m.Files))
+	err := walk(dir, func(rel string, entry Entry) {
+		expected, ok := m.Files[rel]
+		if !ok {
+			if !strict && isCache(rel) {
+				return
+			}
+			res.Extra = append(res.Extra, rel)
+			return
+		}
+		seen[rel] = true
+		if expected != entry {
+			res.Modified = append(res.Modified, rel)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	for rel := range m.Files {
+		if !seen[rel] {
+			res.Missing = append(res.Missing, rel)
+		}
+	}
+	sort.Strings(res.Modified)
+	sort.Strings(res.Missing)
+	sort.Strings(res.Extra)
+	return res, nil
+}
+
+// isCache 判断相对路径中是否有任一部分匹配 cachePatterns
+func isCache(rel string) bool {
+	for _, part := range strings.Split(rel, "/") {
+		for _, pattern := range cachePatterns {
+			if ok, _ := path.Match(pattern, part); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func walk(dir string, fn func(rel string, entry Entry)) error {
+	// 安装目录本身可能是软链接，先解析再遍历
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entry := Entry{Size: info.Size(), Mode: info.Mode()}
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			if entry.Link, err = os.Readlink(p); err != nil {
+				return err
+			}
+			entry.Size = 0
+		case info.Mode().IsRegular():
+			if entry.SHA256, err = hashFile(p); err != nil {
+				return err
+			}
+		}
+		fn(filepath.ToSlash(rel), entry)
+		return nil
+	})
+}
+
+func hashFile(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", name, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/testutil"
+)
+
+func setupInstallDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "bin"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bin", "tool"), []byte("#!/bin/sh"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("readme"), 0644))
+	return dir
+}
+
+func TestVerify(t *testing.T) {
+	dir := setupInstallDir(t)
+	m, err := Generate("tool", "1.0.0", dir)
+	require.NoError(t, err)
+	assert.Len(t, m.Files, 2)
+
+	res, err := Verify(m, dir, false)
+	require.NoError(t, err)
+	assert.True(t, res.OK())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bin", "tool"), []byte("#!/bin/bash"), 0755))
+	require.NoError(t, os.Remove(filepath.Join(dir, "README")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bin", "polluted"), []byte("x"), 0755))
+
+	res, err = Verify(m, dir, false)
+	require.NoError(t, err)
+	assert.False(t, res.OK())
+	assert.Equal(t, []string{"bin/tool"}, res.Modified)
+	assert.Equal(t, []string{"README"}, res.Missing)
+	assert.Equal(t, []string{"bin/polluted"}, res.Extra)
+}
+
+func TestVerify_Extra(t *testing.T) {
+	dir := setupInstallDir(t)
+	m, err := Generate("tool", "1.0.0", dir)
+	require.NoError(t, err)
+
+	// 运行时生成的缓存文件只在严格模式下算作多出的文件
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "lib", "__pycache__"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib", "__pycache__", "tool.cpython-312.pyc"), []byte("x"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib", "tool.pyc"), []byte("x"), 0644))
+
+	res, err := Verify(m, dir, false)
+	require.NoError(t, err)
+	assert.True(t, res.OK())
+	assert.Empty(t, res.Extra)
+
+	res, err = Verify(m, dir, true)
+	require.NoError(t, err)
+	assert.False(t, res.OK())
+	assert.Equal(t, []string{"lib/__pycache__/tool.cpython-312.pyc", "lib/tool.pyc"}, res.Extra)
+
+	// npm install -g、go install 新增的文件在两种模式下都算作损坏
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bin", "gopls"), []byte("x"), 0755))
+	for _, strict := range []bool{false, true} {
+		res, err = Verify(m, dir, strict)
+		require.NoError(t, err)
+		assert.False(t, res.OK())
+		assert.Contains(t, res.Extra, "bin/gopls")
+	}
+}
+
+func TestSaveLoadRemove(t *testing.T) {
+	testutil.SetRootDir(t)
+
+	_, err := Load("tool", "1.0.0")
+	assert.True(t, os.IsNotExist(err))
+
+	m, err := Generate("tool", "1.0.0", setupInstallDir(t))
+	require.NoError(t, err)
+	require.NoError(t, Save(m))
+
+	loaded, err := Load("tool", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, m.Files, loaded.Files)
+
+	require.NoError(t, Remove("tool", "1.0.0"))
+	require.NoError(t, Remove("tool", "1.0.0"))
+	_, err = Load("tool", "1.0.0")
+	assert.True(t, os.IsNotExist(err))
+}
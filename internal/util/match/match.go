@@ -16,7 +16,9 @@ package match
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/go-version"
 	"github.com/sirupsen/logrus"
@@ -33,7 +35,7 @@ func MatchVersion(v string, versions []*version.Version) (*version.Version, erro
 	// 如果是 "latest"，则返回最新的正式版本（非预发布版本）
 	if v == latestVersion {
 		for i := len(versions) - 1; i >= 0; i-- {
-			if versions[i].Prerelease() == "" {
+			if !IsPrerelease(versions[i]) {
 				return versions[i], nil
 			}
 		}
@@ -91,7 +93,7 @@ func MatchVersion(v string, versions []*version.Version) (*version.Version, erro
 			// 返回匹配中的最大正式版本，如果没有正式版本则返回最大版本
 			sort.Sort(version.Collection(matched))
 			for i := len(matched) - 1; i >= 0; i-- {
-				if matched[i].Prerelease() == "" {
+				if !IsPrerelease(matched[i]) {
 					return matched[i], nil
 				}
 			}
@@ -109,3 +111,96 @@ func MatchVersion(v string, versions []*version.Version) (*version.Version, erro
 
 	return nil, fmt.Errorf("version %s not found", v)
 }
+
+// MatchVersions 返回所有匹配 v 的版本（从小到大），"1.2" 会匹配所有 1.2.x，
+// 完整版本号只匹配该版本，"latest" 与 MatchVersion 一致只返回最新正式版本
+func MatchVersions(v string, versions []*version.Version) ([]*version.Version, error) {
+	if v == latestVersion {
+		if len(versions) == 0 {
+			return nil, fmt.Errorf("version %s not found", v)
+		}
+		latest, err := MatchVersion(v, versions)
+		if err != nil {
+			return nil, err
+		}
+		return []*version.Version{latest}, nil
+	}
+
+	ver, err := version.NewVersion(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version format: %s", v)
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		if versions[i].Equal(versions[j]) {
+			return versions[i].String() < versions[j].String()
+		}
+		return versions[i].LessThan(versions[j])
+	})
+
+	dotCount := strings.Count(strings.SplitN(v, "+", 2)[0], ".")
+	inputSegments := ver.Segments()
+	matched := make([]*version.Version, 0)
+	if dotCount < 2 {
+		for _, vItem := range versions {
+			segments := vItem.Segments()
+			if segments[0] != inputSegments[0] {
+				continue
+			}
+			if dotCount == 1 && segments[1] != inputSegments[1] {
+				continue
+			}
+			matched = append(matched, vItem)
+		}
+	} else {
+		// 优先比较字符串，避免 1.2.3 同时匹配到 1.2.3+system 这类带构建元数据的版本
+		for _, vItem := range versions {
+			if vItem.String() == ver.String() {
+				matched = append(matched, vItem)
+			}
+		}
+		if len(matched) == 0 {
+			for _, vItem := range versions {
+				if vItem.Equal(ver) {
+					matched = append(matched, vItem)
+				}
+			}
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("version %s not found", v)
+	}
+	return matched, nil
+}
+
+// prereleasePattern 预发布标识，Java 等语言会把发行版名称放在预发布位置（如 -zulu-xxxx），不视为预发布
+var prereleasePattern = regexp.MustCompile(`^(?i)(alpha|beta|rc|pre|preview|dev|ea|snapshot|nightly|a|b|c)([.\-]?\d.*)?$`)
+
+// IsPrerelease 判断版本是否为预发布版本
+func IsPrerelease(v *version.Version) bool {
+	pre := v.Prerelease()
+	return pre != "" && prereleasePattern.MatchString(pre)
+}
+
+// ParseConstraint 解析版本约束，除 go-version 的逗号分隔格式外，也支持以空格分隔的写法，如 ">=18 <21"
+func ParseConstraint(s string) (version.Constraints, error) {
+	parts := make([]string, 0)
+	pending := ""
+	for _, field := range strings.Fields(strings.ReplaceAll(s, ",", " ")) {
+		// 运算符与版本号之间有空格时（如 ">= 18"），与后面的版本号合并
+		if strings.Trim(field, "<>=!~") == "" {
+			pending += field
+			continue
+		}
+		parts = append(parts, pending+field)
+		pending = ""
+	}
+	if pending != "" || len(parts) == 0 {
+		return nil, fmt.Errorf("invalid version constraint: %s", s)
+	}
+	constraints, err := version.NewConstraint(strings.Join(parts, ","))
+	if err != nil {
+		return nil, fmt.Errorf("invalid version constraint %s: %w", s, err)
+	}
+	return constraints, nil
+}
@@ -152,3 +152,89 @@ func TestMatchVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchVersions(t *testing.T) {
+	versions := []*version.Version{
+		version.Must(version.NewVersion("1.20.3")),
+		version.Must(version.NewVersion("1.20.1")),
+		version.Must(version.NewVersion("1.21.0")),
+		version.Must(version.NewVersion("1.21.0+system")),
+		version.Must(version.NewVersion("2.0.0")),
+	}
+	tests := []struct {
+		name    string
+		v       string
+		want    []string
+		wantErr bool
+	}{
+		{name: "minor", v: "1.20", want: []string{"1.20.1", "1.20.3"}},
+		{name: "major", v: "1", want: []string{"1.20.1", "1.20.3", "1.21.0", "1.21.0+system"}},
+		{name: "exact", v: "1.21.0", want: []string{"1.21.0"}},
+		{name: "metadata", v: "1.21.0+system", want: []string{"1.21.0+system"}},
+		{name: "latest", v: "latest", want: []string{"2.0.0"}},
+		{name: "not-found", v: "1.19", wantErr: true},
+		{name: "invalid", v: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchVersions(tt.v, versions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MatchVersions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			res := make([]string, 0, len(got))
+			for _, v := range got {
+				res = append(res, v.String())
+			}
+			if !tt.wantErr && !reflect.DeepEqual(res, tt.want) {
+				t.Errorf("MatchVersions() got = %v, want %v", res, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPrerelease(t *testing.T) {
+	tests := map[string]bool{
+		"1.22.0":           false,
+		"1.22rc1":          true,
+		"3.13.0a1":         true,
+		"3.13.0b2":         true,
+		"21.0.0-beta.1":    true,
+		"17.0.1-zulu-ab12": false,
+		"2.0.0-preview3":   true,
+	}
+	for v, want := range tests {
+		if got := IsPrerelease(version.Must(version.NewVersion(v))); got != want {
+			t.Errorf("IsPrerelease(%s) = %v, want %v", v, got, want)
+		}
+	}
+}
+
+func TestParseConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+		wantErr    bool
+	}{
+		{constraint: ">=18 <21", version: "20.11.0", want: true},
+		{constraint: ">=18 <21", version: "21.0.0", want: false},
+		{constraint: ">= 18, < 21", version: "18.0.0", want: true},
+		{constraint: "~> 1.22", version: "1.23.4", want: true},
+		{constraint: ">=", wantErr: true},
+		{constraint: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseConstraint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := c.Check(version.Must(version.NewVersion(tt.version))); got != tt.want {
+				t.Errorf("Check(%s) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
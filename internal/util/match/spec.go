@@ -0,0 +1,311 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package match
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+type specKind int
+
+const (
+	kindVersion specKind = iota
+	kindConstraint
+	kindLatest
+	kindStable
+	kindLatestPrerelease
+	kindLTS
+	kindSystem
+)
+
+const (
+	stableVersion           = "stable"
+	latestPrereleaseVersion = "latest-prerelease"
+	ltsVersion              = "lts"
+	// SystemVersion 表示不使用 gvm 管理的版本，而是 PATH 中的系统工具链
+	SystemVersion = "system"
+)
+
+// Candidate 参与解析的版本，Comment 为语言提供的说明，用于识别 LTS 版本
+type Candidate struct {
+	Version *version.Version
+	Comment string
+}
+
+// Spec 版本说明符，支持：
+//   - 完整版本号 1.21.3，或主版本 18、主次版本 1.21
+//   - ~1.21、^18 以及 >=3.10,<3.13 这类约束
+//   - latest、stable、latest-prerelease
+//   - lts、lts/*、lts/hydrogen
+//   - system
+type Spec struct {
+	raw         string
+	alias       string
+	kind        specKind
+	constraints version.Constraints
+	codename    string
+}
+
+// ParseSpec 解析版本说明符
+func ParseSpec(s string) (*Spec, error) {
+	raw := strings.TrimSpace(s)
+	spec := &Spec{raw: raw}
+	lower := strings.ToLower(raw)
+	switch {
+	case raw == "":
+		return nil, fmt.Errorf("version must not be empty")
+	case lower == latestVersion:
+		spec.kind = kindLatest
+	case lower == stableVersion:
+		spec.kind = kindStable
+	case lower == latestPrereleaseVersion:
+		spec.kind = kindLatestPrerelease
+	case lower == SystemVersion:
+		spec.kind = kindSystem
+	case lower == ltsVersion || lower == ltsVersion+"/*":
+		spec.kind = kindLTS
+	case strings.HasPrefix(lower, ltsVersion+"/"):
+		spec.kind = kindLTS
+		spec.codename = strings.TrimPrefix(lower, ltsVersion+"/")
+	case strings.HasPrefix(raw, "^") || (strings.HasPrefix(raw, "~") && !strings.HasPrefix(raw, "~>")):
+		constraints, err := rangeConstraint(raw)
+		if err != nil {
+			return nil, err
+		}
+		spec.kind = kindConstraint
+		spec.constraints = constraints
+	case strings.ContainsAny(raw, "<>=!~, "):
+		constraints, err := ParseConstraint(raw)
+		if err != nil {
+			return nil, err
+		}
+		spec.kind = kindConstraint
+		spec.constraints = constraints
+	default:
+		if _, err := version.NewVersion(raw); err != nil {
+			return nil, fmt.Errorf("invalid version format: %s", raw)
+		}
+		spec.kind = kindVersion
+	}
+	return spec, nil
+}
+
+// ParseAliasSpec 解析别名指向的版本说明符，解析结果会记录别名名称，便于给出更清晰的错误
+func ParseAliasSpec(alias, target string) (*Spec, error) {
+	spec, err := ParseSpec(target)
+	if err != nil {
+		return nil, fmt.Errorf("alias %s: %w", alias, err)
+	}
+	spec.alias = alias
+	return spec, nil
+}
+
+// rangeConstraint 将 npm 风格的 ~ 与 ^ 转换为约束，~1.21 表示 >=1.21.0,<1.22.0，^18 表示 >=18.0.0,<19.0.0
+func rangeConstraint(raw string) (version.Constraints, error) {
+	op, body := raw[:1], strings.TrimPrefix(raw[1:], "v")
+	parts := strings.Split(body, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return nil, fmt.Errorf("invalid version constraint: %s", raw)
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version constraint: %s", raw)
+		}
+		nums[i] = n
+	}
+
+	upper := make([]int, 3)
+	switch {
+	case op == "~" && len(parts) == 1:
+		upper[0] = nums[0] + 1
+	case op == "~":
+		upper[0], upper[1] = nums[0], nums[1]+1
+	case nums[0] > 0 || len(parts) == 1:
+		upper[0] = nums[0] + 1
+	case nums[1] > 0 || len(parts) == 2:
+		upper[1] = nums[1] + 1
+	default:
+		upper[1], upper[2] = nums[1], nums[2]+1
+	}
+	return version.NewConstraint(fmt.Sprintf(">= %d.%d.%d, < %d.%d.%d",
+		nums[0], nums[1], nums[2], upper[0], upper[1], upper[2]))
+}
+
+// String 返回原始的版本说明符
+func (s *Spec) String() string {
+	return s.raw
+}
+
+// Alias 返回说明符来自的别名，不是别名时为空
+func (s *Spec) Alias() string {
+	return s.alias
+}
+
+// IsSystem 判断是否为 system，此时应使用系统工具链而不是解析版本
+func (s *Spec) IsSystem() bool {
+	return s.kind == kindSystem
+}
+
+// NeedsComment 判断解析时是否依赖 Candidate.Comment，已安装版本没有说明时需要从远程版本补充
+func (s *Spec) NeedsComment() bool {
+	return s.kind == kindLTS
+}
+
+// Check 判断版本是否满足约束，Java 等语言放在预发布位置的发行版名称不参与比较
+func Check(c version.Constraints, v *version.Version) bool {
+	if v.Prerelease() != "" && !IsPrerelease(v) {
+		return c.Check(v.Core())
+	}
+	return c.Check(v)
+}
+
+// MatchAll 返回所有满足说明符的版本（从小到大）；latest、stable 和 latest-prerelease 只返回选中的一个版本
+func (s *Spec) MatchAll(candidates []*Candidate) ([]*Candidate, error) {
+	sorted := make([]*Candidate, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Version.Equal(sorted[j].Version) {
+			return sorted[i].Version.String() < sorted[j].Version.String()
+		}
+		return sorted[i].Version.LessThan(sorted[j].Version)
+	})
+
+	res := make([]*Candidate, 0)
+	switch s.kind {
+	case kindSystem:
+		return nil, fmt.Errorf("version %s can not be resolved to an installed version", s.raw)
+	case kindVersion:
+		versions := make([]*version.Version, 0, len(sorted))
+		byVersion := make(map[*version.Version]*Candidate, len(sorted))
+		for _, c := range sorted {
+			versions = append(versions, c.Version)
+			byVersion[c.Version] = c
+		}
+		matched, err := MatchVersions(strings.TrimPrefix(s.raw, "v"), versions)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range matched {
+			res = append(res, byVersion[v])
+		}
+		return res, nil
+	case kindConstraint:
+		for _, c := range sorted {
+			if Check(s.constraints, c.Version) {
+				res = append(res, c)
+			}
+		}
+	case kindLTS:
+		for _, c := range sorted {
+			if s.isLTS(c.Comment) && !IsPrerelease(c.Version) {
+				res = append(res, c)
+			}
+		}
+	case kindLatest, kindStable, kindLatestPrerelease:
+		c, err := s.Match(candidates)
+		if err != nil {
+			return nil, err
+		}
+		return []*Candidate{c}, nil
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("version %s not found", s.raw)
+	}
+	return res, nil
+}
+
+// Match 返回满足说明符的最佳版本，优先选择最新的正式版本
+func (s *Spec) Match(candidates []*Candidate) (*Candidate, error) {
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("version %s not found", s.raw)
+	}
+	var stable, pre *Candidate
+	newer := func(c, than *Candidate) bool {
+		return than == nil || c.Version.GreaterThan(than.Version)
+	}
+	switch s.kind {
+	case kindVersion:
+		// 主版本、主次版本沿用 MatchVersion 的规则，如 Java 的 17 优先匹配 17.0.0
+		versions := make([]*version.Version, 0, len(candidates))
+		byVersion := make(map[*version.Version]*Candidate, len(candidates))
+		for _, c := range candidates {
+			versions = append(versions, c.Version)
+			byVersion[c.Version] = c
+		}
+		v, err := MatchVersion(strings.TrimPrefix(s.raw, "v"), versions)
+		if err != nil {
+			return nil, err
+		}
+		return byVersion[v], nil
+	case kindLatest, kindStable, kindLatestPrerelease:
+		for _, c := range candidates {
+			if IsPrerelease(c.Version) {
+				if newer(c, pre) {
+					pre = c
+				}
+			} else if newer(c, stable) {
+				stable = c
+			}
+		}
+	default:
+		matched, err := s.MatchAll(candidates)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range matched {
+			if IsPrerelease(c.Version) {
+				if newer(c, pre) {
+					pre = c
+				}
+			} else if newer(c, stable) {
+				stable = c
+			}
+		}
+	}
+
+	switch {
+	case s.kind == kindLatestPrerelease && pre != nil:
+		return pre, nil
+	case s.kind == kindLatestPrerelease:
+		return nil, fmt.Errorf("no prerelease version found")
+	case stable != nil:
+		return stable, nil
+	case s.kind != kindStable && pre != nil:
+		// latest 和约束在没有正式版本时退回到最新的预发布版本
+		return pre, nil
+	default:
+		return nil, fmt.Errorf("version %s not found", s.raw)
+	}
+}
+
+// isLTS 判断说明是否为 LTS 版本，指定代号时需要代号一致，如 node 的 "LTS: Hydrogen"
+func (s *Spec) isLTS(comment string) bool {
+	lower := strings.ToLower(comment)
+	if !strings.Contains(lower, ltsVersion) {
+		return false
+	}
+	if s.codename == "" {
+		return true
+	}
+	name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(lower, ltsVersion)), ":"))
+	return name == s.codename
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package match
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func candidates(comments map[string]string, versions ...string) []*Candidate {
+	res := make([]*Candidate, 0, len(versions))
+	for _, v := range versions {
+		res = append(res, &Candidate{Version: version.Must(version.NewVersion(v)), Comment: comments[v]})
+	}
+	return res
+}
+
+func TestSpec_Match(t *testing.T) {
+	comments := map[string]string{
+		"18.20.4": "LTS: Hydrogen",
+		"20.17.0": "LTS: Iron",
+		"20.18.0": "LTS: Iron",
+	}
+	node := candidates(comments, "18.20.4", "19.9.0", "20.17.0", "20.18.0", "21.7.3", "22.0.0-rc.1")
+	python := candidates(nil, "3.9.19", "3.10.14", "3.12.4", "3.13.0b1", "3.13.0")
+	golang := candidates(nil, "1.20.14", "1.21.0", "1.21.13", "1.22.6", "1.23rc2")
+
+	tests := []struct {
+		spec       string
+		candidates []*Candidate
+		want       string
+		wantErr    bool
+	}{
+		{spec: "latest", candidates: node, want: "21.7.3"},
+		{spec: "stable", candidates: python, want: "3.13.0"},
+		{spec: "latest-prerelease", candidates: golang, want: "1.23.0-rc2"},
+		{spec: "latest-prerelease", candidates: node[:2], wantErr: true},
+		{spec: "lts", candidates: node, want: "20.18.0"},
+		{spec: "lts/*", candidates: node, want: "20.18.0"},
+		{spec: "lts/hydrogen", candidates: node, want: "18.20.4"},
+		{spec: "lts/argon", candidates: node, wantErr: true},
+		{spec: "~1.21", candidates: golang, want: "1.21.13"},
+		{spec: "^18", candidates: node, want: "18.20.4"},
+		{spec: "^20.17.5", candidates: node, want: "20.18.0"},
+		{spec: ">=3.10,<3.13", candidates: python, want: "3.12.4"},
+		{spec: ">=3.10 <3.12", candidates: python, want: "3.10.14"},
+		{spec: "1.21", candidates: golang, want: "1.21.13"},
+		{spec: "1.21.0", candidates: golang, want: "1.21.0"},
+		{spec: "v18", candidates: node, want: "18.20.4"},
+		{spec: "1.19", candidates: golang, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			spec, err := ParseSpec(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSpec(%s) error = %v", tt.spec, err)
+			}
+			got, err := spec.Match(tt.candidates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Match() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Version.String() != tt.want {
+				t.Errorf("Match() = %s, want %s", got.Version.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSpec_MatchAll(t *testing.T) {
+	golang := candidates(nil, "1.22.6", "1.20.14", "1.21.13", "1.21.0")
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{spec: "1.21", want: []string{"1.21.0", "1.21.13"}},
+		{spec: "~1.21", want: []string{"1.21.0", "1.21.13"}},
+		{spec: "<1.22", want: []string{"1.20.14", "1.21.0", "1.21.13"}},
+		{spec: "latest", want: []string{"1.22.6"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			spec, err := ParseSpec(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSpec(%s) error = %v", tt.spec, err)
+			}
+			matched, err := spec.MatchAll(golang)
+			if err != nil {
+				t.Fatalf("MatchAll() error = %v", err)
+			}
+			got := make([]string, 0, len(matched))
+			for _, c := range matched {
+				got = append(got, c.Version.String())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("MatchAll() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("MatchAll() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	for _, s := range []string{"", "^abc", "~1.2.3.4", ">=", "not-a-version"} {
+		if _, err := ParseSpec(s); err == nil {
+			t.Errorf("ParseSpec(%q) expected error", s)
+		}
+	}
+	spec, err := ParseSpec("system")
+	if err != nil || !spec.IsSystem() {
+		t.Errorf("ParseSpec(system) = %v, %v", spec, err)
+	}
+	if spec, _ := ParseSpec("lts/iron"); !spec.NeedsComment() {
+		t.Errorf("lts/iron should need comments")
+	}
+}
@@ -49,11 +49,12 @@ func GetInstalledVersion(lang, binPath string) ([]string, error) {
 	versions := make([]string, 0)
 
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		if entry.Name() == Current {
 			continue
 		}
 
-		if entry.Name() == Current {
+		// 通过 gvm link 登记的版本是指向外部目录的软链接
+		if !entry.IsDir() && !IsDir(path.Join(installedDir, entry.Name())) {
 			continue
 		}
 
@@ -85,3 +86,12 @@ func IsPathExist(dir string) bool {
 	}
 	return true
 }
+
+// IsDir 判断路径是否为目录，会跟随软链接
+func IsDir(dir string) bool {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return false
+	}
+	return info.IsDir()
+}
@@ -151,3 +151,22 @@ func TestIsPathExist(t *testing.T) {
 		t.Errorf("expected path to not exist: %s", nonexistent)
 	}
 }
+
+func TestGetInstalledVersion_SymlinkedVersion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink test skipped on Windows")
+	}
+	root := setupTestRoot(t)
+	external := filepath.Join(t.TempDir(), "jdk")
+	os.MkdirAll(filepath.Join(external, "bin"), 0755)
+	os.MkdirAll(filepath.Join(root, "java"), 0755)
+	os.Symlink(external, filepath.Join(root, "java", "17.0.9+system"))
+
+	versions, err := gvmpath.GetInstalledVersion("java", "bin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 1 || versions[0] != "17.0.9+system" {
+		t.Errorf("expected [17.0.9+system], got: %v", versions)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/file"
+)
+
+const (
+	usageFile = ".usage.json"
+)
+
+// Usage 记录每个语言版本最近一次被使用的时间，key 依次为语言名称和版本目录名
+type Usage map[string]map[string]time.Time
+
+// GetPath 返回使用记录文件路径
+func GetPath() string {
+	return filepath.Join(core.GetRootDir(), usageFile)
+}
+
+// Load 读取使用记录，文件不存在时返回空记录
+func Load() (Usage, error) {
+	u := make(Usage)
+	if _, err := os.Stat(GetPath()); os.IsNotExist(err) {
+		return u, nil
+	}
+	if err := file.ReadJSONFile(GetPath(), &u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// LastUsed 返回指定版本最近一次被使用的时间
+func (u Usage) LastUsed(lang, version string) (time.Time, bool) {
+	t, ok := u[lang][version]
+	return t, ok
+}
+
+// Record 记录指定版本在 t 时刻被使用
+func Record(lang, version string, t time.Time) error {
+	return update(func(u Usage) {
+		if u[lang] == nil {
+			u[lang] = make(map[string]time.Time)
+		}
+		u[lang][version] = t
+	})
+}
+
+// Remove 删除指定版本的使用记录，版本卸载时调用
+func Remove(lang, version string) error {
+	if _, err := os.Stat(GetPath()); os.IsNotExist(err) {
+		return nil
+	}
+	return update(func(u Usage) {
+		delete(u[lang], version)
+		if len(u[lang]) == 0 {
+			delete(u, lang)
+		}
+	})
+}
+
+// update 读取、修改并写回使用记录，写入临时文件后重命名，避免并发执行时读到半个文件
+func update(fn func(u Usage)) error {
+	u, err := Load()
+	if err != nil {
+		return err
+	}
+	fn(u)
+
+	target := GetPath()
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(target), err)
+	}
+	tmp := fmt.Sprintf("%s.%d.tmp", target, os.Getpid())
+	if err := file.WriteJSONFile(tmp, u); err != nil {
+		return err
+	}
+	return os.Rename(tmp, target)
+}
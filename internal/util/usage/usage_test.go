@@ -0,0 +1,52 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/testutil"
+)
+
+func TestRecordRemove(t *testing.T) {
+	testutil.SetRootDir(t)
+
+	u, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, u)
+	require.NoError(t, Remove("go", "1.21.0"))
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, Record("go", "1.21.0", now))
+	require.NoError(t, Record("go", "1.20.3", now.Add(-time.Hour)))
+
+	u, err = Load()
+	require.NoError(t, err)
+	last, ok := u.LastUsed("go", "1.21.0")
+	assert.True(t, ok)
+	assert.True(t, now.Equal(last))
+	_, ok = u.LastUsed("node", "20.0.0")
+	assert.False(t, ok)
+
+	require.NoError(t, Remove("go", "1.21.0"))
+	require.NoError(t, Remove("go", "1.20.3"))
+	u, err = Load()
+	require.NoError(t, err)
+	assert.Empty(t, u)
+}
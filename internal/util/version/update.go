@@ -34,8 +34,8 @@ type Release struct {
 
 func CheckUpdate(ctx context.Context) (has bool, latest string) {
 	logger := log.GetLogger(ctx)
-	const url = "https://api.github.com/repos/toodofun/gvm/releases/latest"
-	body, err := http.Default().Get(ctx, url)
+	const url = http.PublicGitHubAPIURL + "/repos/toodofun/gvm/releases/latest"
+	body, _, err := http.Default().GetGitHub(ctx, url, "")
 	if err != nil {
 		logger.Debugf("Failed to get latest version from %s: %s", url, err)
 		return false, ""
@@ -18,11 +18,14 @@ import (
 	"context"
 	"sort"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/toodofun/gvm/i18n"
 
 	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/util/eol"
+	"github.com/toodofun/gvm/internal/util/usage"
 
 	"github.com/duke-git/lancet/v2/slice"
 	"github.com/gdamore/tcell/v2"
@@ -31,6 +34,8 @@ import (
 type version struct {
 	*core.RemoteVersion
 	location    string
+	lastUsed    string
+	lifecycle   string
 	isInstalled bool
 	isDefault   bool
 }
@@ -64,23 +69,52 @@ func (lv *LanguageVersions) GetData(ctx context.Context, lang core.Language) (*L
 		installedVersionList[iv.Version.String()] = iv
 	}
 	current := lang.GetDefaultVersion(ctx)
+	records, err := usage.Load()
+	if err != nil {
+		records = usage.Usage{}
+	}
+	lastUsed := func(iv *core.InstalledVersion) string {
+		if t, ok := records.LastUsed(lang.Name(), iv.Origin); ok {
+			return t.Local().Format("2006-01-02 15:04")
+		}
+		return ""
+	}
+	cycles := eol.Load(lang.Name())
+	now := time.Now()
 
 	versions := make([]*version, 0)
 
 	if len(rvs) > 0 {
+		remoteVersionList := make(map[string]bool)
+		for _, rv := range rvs {
+			remoteVersionList[rv.Version.String()] = true
+		}
+		// 通过本地安装包或 gvm link 登记的版本可能不在远程列表中
+		for _, iv := range installedVersions {
+			if !remoteVersionList[iv.Version.String()] {
+				rvs = append(rvs, &core.RemoteVersion{
+					Version: iv.Version,
+					Origin:  iv.Origin,
+				})
+			}
+		}
+		sort.Slice(rvs, func(i, j int) bool {
+			return rvs[i].Version.GreaterThan(rvs[j].Version)
+		})
 		for _, rv := range rvs {
 			if iv, ok := installedVersionList[rv.Version.String()]; ok {
 				versions = append(versions, &version{
 					RemoteVersion: rv,
 					isInstalled:   ok,
-					isDefault:     current.Version.Equal(rv.Version),
+					isDefault:     current.Version.String() == rv.Version.String(),
 					location:      iv.Location,
+					lastUsed:      lastUsed(iv),
 				})
 			} else {
 				versions = append(versions, &version{
 					RemoteVersion: rv,
 					isInstalled:   ok,
-					isDefault:     current.Version.Equal(rv.Version),
+					isDefault:     current.Version.String() == rv.Version.String(),
 					location:      "",
 				})
 			}
@@ -93,11 +127,15 @@ func (lv *LanguageVersions) GetData(ctx context.Context, lang core.Language) (*L
 					Origin:  iv.Origin,
 				},
 				isInstalled: true,
-				isDefault:   current.Version.Equal(iv.Version),
+				isDefault:   current.Version.String() == iv.Version.String(),
 				location:    iv.Location,
+				lastUsed:    lastUsed(iv),
 			})
 		}
 	}
+	for _, v := range versions {
+		v.lifecycle = eol.Status(cycles, v.Version, now)
+	}
 
 	return &LanguageVersions{
 		versions:  versions,
@@ -142,6 +180,10 @@ func (lv *LanguageVersions) Headers() []*TableHeader {
 			Title:     i18n.GetTranslate("page.languageVersion.table.header.location", nil),
 			Expansion: 1,
 		},
+		{
+			Title:      i18n.GetTranslate("page.languageVersion.table.header.lastUsed", nil),
+			FixedWidth: 16,
+		},
 		{
 			Title: i18n.GetTranslate("page.languageVersion.table.header.installed", nil),
 			Hide:  true,
@@ -160,7 +202,11 @@ func (lv *LanguageVersions) Rows() [][]string {
 		if v.isInstalled {
 			isInstalled = "true"
 		}
-		res = append(res, []string{vs, v.Comment, v.location, isInstalled})
+		comment := v.Comment
+		if v.lifecycle != "" {
+			comment = strings.TrimSpace(comment + " " + v.lifecycle)
+		}
+		res = append(res, []string{vs, comment, v.location, v.lastUsed, isInstalled})
 	}
 	return res
 }
@@ -189,7 +235,7 @@ func (lv *LanguageVersions) Filter(k, v string) {
 }
 
 func (lv *LanguageVersions) GetRowColor(i []string) tcell.Color {
-	if i[3] == "true" {
+	if i[len(i)-1] == "true" {
 		return tcell.ColorGreen
 	} else {
 		return tcell.ColorSkyblue
@@ -159,6 +159,9 @@ func (i *Installer) Install() {
 		if err != nil {
 			i.write("install failed: " + err.Error())
 		} else {
+			if e := languages.RecordManifest(ctx, i.lang, i.version.Version.String()); e != nil {
+				log.GetLogger(ctx).Warnf("%v", e)
+			}
 			i.write("Installation completed successfully!")
 		}
 
@@ -0,0 +1,446 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package asdf 将 asdf 插件（bin/list-all、bin/download、bin/install 等脚本）适配为 gvm 的语言
+package asdf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/log"
+	"github.com/toodofun/gvm/internal/util/env"
+	"github.com/toodofun/gvm/internal/util/path"
+	"github.com/toodofun/gvm/languages"
+
+	"github.com/duke-git/lancet/v2/slice"
+	goversion "github.com/hashicorp/go-version"
+)
+
+const (
+	// pluginDir 从 git 克隆的插件所在的目录，位于 gvm 根目录下
+	pluginDir = ".asdf-plugins"
+	// downloadDir bin/download 的下载目录，安装完成后删除
+	downloadDir = ".cache/asdf"
+	// originDir 记录安装时使用的原始版本号，卸载时同样传给 bin/uninstall
+	originDir = ".asdf-origins"
+)
+
+var (
+	// required asdf 插件必须提供的脚本
+	required = []string{"list-all", "install"}
+	// envKey exec-env 输出中的环境变量
+	envKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+	// ignoredEnv 执行 exec-env 时由 shell 自动设置或由 list-bin-paths 负责的变量
+	ignoredEnv = []string{"PATH", "PWD", "OLDPWD", "SHLVL", "_"}
+)
+
+// Asdf 使用 asdf 插件作为数据源的插件
+type Asdf struct {
+	name   string
+	source string
+	dir    string
+
+	mu sync.Mutex
+	// envs 按版本目录名缓存 list-bin-paths 和 exec-env 的结果，没有默认版本时键为空
+	envs map[string][]env.KV
+}
+
+// isGitURL 判断数据源是否为 git 仓库地址，否则视为本地插件目录
+func isGitURL(source string) bool {
+	for _, prefix := range []string{"https://", "http://", "git@", "ssh://", "git://", "file://"} {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	return strings.HasSuffix(source, ".git")
+}
+
+// NewAsdf 创建 asdf 插件，数据源为插件目录或 git 仓库地址；git 仓库在第一次使用时克隆到 gvm 根目录下
+func NewAsdf(name, dsn string) (*Asdf, error) {
+	if runtime.GOOS == env.RuntimeFromWindows {
+		return nil, fmt.Errorf("asdf plugins are shell scripts and are not supported on windows")
+	}
+	if dsn == "" {
+		return nil, fmt.Errorf("invalid data source name, expected a plugin directory or git url")
+	}
+	a := &Asdf{name: name, source: dsn, envs: make(map[string][]env.KV)}
+	if isGitURL(dsn) {
+		a.dir = filepath.Join(core.GetRootDir(), pluginDir, name)
+		return a, nil
+	}
+
+	// 数据源保存在配置中，相对路径在其他目录下执行时会失效
+	dir := dsn
+	if home, ok := strings.CutPrefix(dsn, "~/"); ok {
+		dir = filepath.Join(os.Getenv("HOME"), home)
+	}
+	if !filepath.IsAbs(dir) {
+		return nil, fmt.Errorf("plugin directory %s must be an absolute path", dsn)
+	}
+	if err := checkPlugin(dir); err != nil {
+		return nil, err
+	}
+	a.dir = dir
+	return a, nil
+}
+
+// checkPlugin 检查目录中是否有 asdf 插件必需的脚本
+func checkPlugin(dir string) error {
+	for _, script := range required {
+		if _, err := os.Stat(filepath.Join(dir, "bin", script)); err != nil {
+			return fmt.Errorf("%s is not an asdf plugin, bin/%s is missing", dir, script)
+		}
+	}
+	return nil
+}
+
+// ensure 确保插件目录可用，git 仓库尚未克隆时先克隆
+func (a *Asdf) ensure(ctx context.Context) error {
+	if !isGitURL(a.source) {
+		return nil
+	}
+	if _, err := os.Stat(a.dir); os.IsNotExist(err) {
+		log.GetLogger(ctx).Infof("Cloning asdf plugin %s", a.source)
+		if err := os.MkdirAll(filepath.Dir(a.dir), 0755); err != nil {
+			return err
+		}
+		out, err := exec.CommandContext(ctx, "git", "clone", "--depth", "1", a.source, a.dir).CombinedOutput()
+		if err != nil {
+			_ = os.RemoveAll(a.dir)
+			return fmt.Errorf("failed to clone %s: %w: %s", a.source, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return checkPlugin(a.dir)
+}
+
+// hasScript 判断插件是否提供了可选脚本
+func (a *Asdf) hasScript(script string) bool {
+	_, err := os.Stat(filepath.Join(a.dir, "bin", script))
+	return err == nil
+}
+
+// command 创建执行插件脚本的命令，按 asdf 的约定通过环境变量传递版本和目录
+func (a *Asdf) command(ctx context.Context, script, version, installPath, downloadPath string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, filepath.Join(a.dir, "bin", script))
+	cmd.Dir = a.dir
+	cmd.Env = append(os.Environ(),
+		"ASDF_INSTALL_TYPE=version",
+		"ASDF_INSTALL_VERSION="+version,
+		"ASDF_INSTALL_PATH="+installPath,
+		"ASDF_DOWNLOAD_PATH="+downloadPath,
+		"ASDF_PLUGIN_PATH="+a.dir,
+		"ASDF_CONCURRENCY="+strconv.Itoa(runtime.NumCPU()),
+	)
+	return cmd
+}
+
+// output 执行脚本并返回标准输出，失败时错误中包含标准错误
+func (a *Asdf) output(cmd *exec.Cmd) (string, error) {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s failed: %w: %s", filepath.Base(cmd.Path), err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// run 执行脚本，输出显示给用户
+func (a *Asdf) run(ctx context.Context, cmd *exec.Cmd) error {
+	cmd.Stdout = log.GetStdout(ctx)
+	cmd.Stderr = log.GetStderr(ctx)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w", filepath.Base(cmd.Path), err)
+	}
+	return nil
+}
+
+func (a *Asdf) Name() string {
+	return a.name
+}
+
+// Executable asdf 插件没有声明可执行文件，使用插件名称
+func (a *Asdf) Executable() string {
+	return a.name
+}
+
+// ListRemoteVersions 执行 bin/list-all，输出为空格分隔、从旧到新的版本
+func (a *Asdf) ListRemoteVersions(ctx context.Context) ([]*core.RemoteVersion, error) {
+	logger := log.GetLogger(ctx)
+	if err := a.ensure(ctx); err != nil {
+		return nil, err
+	}
+	out, err := a.output(a.command(ctx, "list-all", "", "", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*core.RemoteVersion, 0)
+	skipped := make([]string, 0)
+	for _, v := range strings.Fields(out) {
+		ver, err := goversion.NewVersion(strings.TrimPrefix(v, "v"))
+		if err != nil {
+			skipped = append(skipped, v)
+			continue
+		}
+		comment := "Stable Release"
+		if ver.Prerelease() != "" {
+			comment = "Prerelease"
+		}
+		res = append(res, &core.RemoteVersion{Version: ver, Origin: v, Comment: comment})
+	}
+	// 如 temurin-17.0.9+9 这类带发行版前缀的版本无法按版本号比较和选择，只能跳过
+	if len(skipped) > 0 {
+		logger.Warnf("Skip %d versions of %s that are not version numbers, such as %s",
+			len(skipped), a.Name(), strings.Join(skipped[:min(len(skipped), 3)], ", "))
+	}
+	return res, nil
+}
+
+func (a *Asdf) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVersion, error) {
+	return languages.NewLanguage(a).ListInstalledVersions(ctx, "")
+}
+
+// Install 依次执行 bin/download（如果有）和 bin/install，失败时删除安装目录
+func (a *Asdf) Install(ctx context.Context, remoteVersion *core.RemoteVersion) error {
+	logger := log.GetLogger(ctx)
+	logger.Infof("Install remote version %s", remoteVersion.Origin)
+	if err, exist := languages.HasInstall(ctx, a, *remoteVersion.Version); err != nil || exist {
+		return err
+	}
+	if err := a.ensure(ctx); err != nil {
+		return err
+	}
+
+	version := remoteVersion.Version.String()
+	dest := filepath.Join(path.GetLangRoot(a.Name()), version)
+	download := filepath.Join(core.GetRootDir(), downloadDir, a.Name(), version)
+	defer func() { _ = os.RemoveAll(download) }()
+	for _, dir := range []string{dest, download} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	for _, script := range []string{"download", "install"} {
+		if script == "download" && !a.hasScript(script) {
+			continue
+		}
+		if err := a.run(ctx, a.command(ctx, script, remoteVersion.Origin, dest, download)); err != nil {
+			if rmErr := os.RemoveAll(dest); rmErr != nil {
+				logger.Warnf("Failed to clean %s: %v", dest, rmErr)
+			}
+			return fmt.Errorf("failed to install version %s: %w", version, err)
+		}
+	}
+	if err := a.saveOrigin(version, remoteVersion.Origin); err != nil {
+		logger.Warnf("Failed to record the original version of %s: %v", version, err)
+	}
+	logger.Infof("Version %s was successfully installed in %s", version, dest)
+	return nil
+}
+
+func (a *Asdf) originFile(version string) string {
+	return filepath.Join(core.GetRootDir(), originDir, a.Name(), version)
+}
+
+func (a *Asdf) saveOrigin(version, origin string) error {
+	file := a.originFile(version)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, []byte(origin), 0644)
+}
+
+// origin 返回安装时的原始版本号（如 v1.2.0），没有记录时使用目录名
+func (a *Asdf) origin(version string) string {
+	data, err := os.ReadFile(a.originFile(version))
+	if err != nil || len(data) == 0 {
+		return version
+	}
+	return string(data)
+}
+
+// Envs 返回当前默认版本的环境变量
+func (a *Asdf) Envs() []env.KV {
+	version := ""
+	if current := languages.NewLanguage(a).GetDefaultVersion(); len(current.Location) > 0 {
+		version = filepath.Base(current.Location)
+	}
+	return a.envsOf(context.Background(), version)
+}
+
+// envsOf 将 bin/list-bin-paths 输出的目录（默认为 bin）加入 PATH，并设置 bin/exec-env 导出的环境变量。
+// 脚本以该版本的原始版本号和安装目录执行，输出中的安装目录替换为 current，切换版本时无需改写；
+// 脚本执行失败时不缓存结果
+func (a *Asdf) envsOf(ctx context.Context, version string) []env.KV {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if envs, ok := a.envs[version]; ok {
+		return envs
+	}
+	logger := log.GetLogger(ctx)
+	current := filepath.Join(path.GetLangRoot(a.Name()), path.Current)
+	origin, installPath := "", current
+	if version != "" {
+		origin, installPath = a.origin(version), filepath.Join(path.GetLangRoot(a.Name()), version)
+	}
+	failed := false
+
+	dirs := []string{"bin"}
+	if a.hasScript("list-bin-paths") {
+		out, err := a.output(a.command(ctx, "list-bin-paths", origin, installPath, ""))
+		if err != nil {
+			logger.Warnf("%v", err)
+			failed = true
+		} else if fields := strings.Fields(out); len(fields) > 0 {
+			dirs = fields
+		}
+	}
+	envs := make([]env.KV, 0, len(dirs))
+	for _, dir := range dirs {
+		envs = append(envs, env.KV{Key: "PATH", Value: filepath.Join(current, dir), Append: true})
+	}
+
+	if a.hasScript("exec-env") {
+		vars, err := a.execEnv(ctx, origin, installPath)
+		if err != nil {
+			logger.Warnf("%v", err)
+			failed = true
+		}
+		keys := make([]string, 0, len(vars))
+		for k := range vars {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			envs = append(envs, env.KV{Key: k, Value: strings.ReplaceAll(vars[k], installPath, current)})
+		}
+	}
+	if !failed {
+		a.envs[version] = envs
+	}
+	return envs
+}
+
+// execEnv 在 shell 中引入 bin/exec-env，返回其新增或修改的环境变量
+func (a *Asdf) execEnv(ctx context.Context, version, installPath string) (map[string]string, error) {
+	script := filepath.Join(a.dir, "bin", "exec-env")
+	cmd := a.command(ctx, "exec-env", version, installPath, "")
+	cmd.Path, cmd.Args = "/bin/sh", []string{"sh", "-c", `. "$0" >/dev/null 2>&1; env`, script}
+	if bash, err := exec.LookPath("bash"); err == nil {
+		cmd.Path, cmd.Args[0] = bash, "bash"
+	}
+	before := make(map[string]string)
+	for _, kv := range cmd.Env {
+		k, v, _ := strings.Cut(kv, "=")
+		before[k] = v
+	}
+	out, err := a.output(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	after := make(map[string]string)
+	last := ""
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if !envKey.MatchString(line) {
+			// 多行的值
+			if last != "" {
+				after[last] += "\n" + line
+			}
+			continue
+		}
+		k, v, _ := strings.Cut(line, "=")
+		after[k], last = v, k
+	}
+
+	res := make(map[string]string)
+	for k, v := range after {
+		if old, ok := before[k]; (ok && old == v) || slice.Contain(ignoredEnv, k) {
+			continue
+		}
+		res[k] = v
+	}
+	return res, nil
+}
+
+func (a *Asdf) SetDefaultVersion(ctx context.Context, version string) error {
+	return languages.NewLanguage(a).SetDefaultVersion(ctx, version, a.envsOf(ctx, version))
+}
+
+func (a *Asdf) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
+	return languages.NewLanguage(a).GetDefaultVersion()
+}
+
+// Uninstall 插件提供了 bin/uninstall 时先执行，该脚本可能已删除安装目录，之后由 gvm 清理剩余内容
+func (a *Asdf) Uninstall(ctx context.Context, version string) error {
+	if err := a.uninstall(ctx, version); err != nil {
+		return err
+	}
+	if err := os.Remove(a.originFile(version)); err != nil && !os.IsNotExist(err) {
+		log.GetLogger(ctx).Warnf("Failed to clean %s: %v", a.originFile(version), err)
+	}
+	a.mu.Lock()
+	delete(a.envs, version)
+	a.mu.Unlock()
+	return nil
+}
+
+func (a *Asdf) uninstall(ctx context.Context, version string) error {
+	lang := languages.NewLanguage(a)
+	dest := filepath.Join(path.GetLangRoot(a.Name()), version)
+	// 在脚本删除安装目录之前取得需要清理的环境变量
+	envs := a.Envs()
+	if !a.hasScript("uninstall") {
+		return lang.Uninstall(ctx, version, envs)
+	}
+
+	current := lang.GetDefaultVersion()
+	isCurrent := len(current.Location) > 0 && filepath.Base(current.Location) == version
+	if err := a.run(ctx, a.command(ctx, "uninstall", a.origin(version), dest, "")); err != nil {
+		return err
+	}
+	// 安装目录已被脚本删除时 lang.Uninstall 只清理记录，需要在这里取消默认版本
+	removed := !path.IsPathExist(dest)
+	if err := lang.Uninstall(ctx, version, envs); err != nil {
+		return err
+	}
+	if removed && isCurrent {
+		return lang.UnsetDefaultVersion(ctx, envs)
+	}
+	return nil
+}
+
+func (a *Asdf) UnsetDefaultVersion(ctx context.Context) error {
+	return languages.NewLanguage(a).UnsetDefaultVersion(ctx, a.Envs())
+}
+
+func init() {
+	core.RegisterAddonProvider("asdf", func(name, dsn string) (core.Language, error) {
+		return NewAsdf(name, dsn)
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asdf
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/testutil"
+	"github.com/toodofun/gvm/internal/util/env"
+)
+
+// fakePlugin 按 asdf 的约定生成插件脚本：download 写入下载目录，install 从下载目录复制到安装目录
+func fakePlugin(t *testing.T, dir string) {
+	t.Helper()
+	scripts := map[string]string{
+		"list-all": `echo "1.0.0 v1.1.0 2.0.0-rc.1 nightly"`,
+		"download": `echo "$ASDF_INSTALL_VERSION" > "$ASDF_DOWNLOAD_PATH/tool.txt"`,
+		"install": `mkdir -p "$ASDF_INSTALL_PATH/libexec"
+cp "$ASDF_DOWNLOAD_PATH/tool.txt" "$ASDF_INSTALL_PATH/libexec/"
+[ "$ASDF_INSTALL_VERSION" != "2.0.0-rc.1" ]`,
+		"uninstall":      `echo "$ASDF_INSTALL_VERSION" > "$ASDF_INSTALL_PATH/../uninstalled.txt"` + "\n" + `rm -rf "$ASDF_INSTALL_PATH"`,
+		"list-bin-paths": `echo "libexec shims"`,
+		"exec-env": `export TOOL_HOME="$ASDF_INSTALL_PATH"` + "\n" + `export TOOL_VERSION="$ASDF_INSTALL_VERSION"` + "\n" +
+			`export PATH="$ASDF_INSTALL_PATH/other:$PATH"`,
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "bin"), 0755))
+	for name, body := range scripts {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "bin", name), []byte("#!/bin/sh\n"+body+"\n"), 0755))
+	}
+}
+
+func TestAsdf(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("asdf plugins are not supported on windows")
+	}
+	root := testutil.SetRootDir(t)
+	pluginPath := filepath.Join(t.TempDir(), "asdf-tool")
+	fakePlugin(t, pluginPath)
+	ctx := context.Background()
+
+	a, err := NewAsdf("tool", pluginPath)
+	require.NoError(t, err)
+	versions, err := a.ListRemoteVersions(ctx)
+	require.NoError(t, err)
+	require.Len(t, versions, 3, "nightly is not a version")
+	assert.Equal(t, "v1.1.0", versions[1].Origin)
+	assert.Equal(t, "Prerelease", versions[2].Comment)
+
+	require.NoError(t, a.Install(ctx, versions[1]))
+	data, err := os.ReadFile(filepath.Join(root, "tool", "1.1.0", "libexec", "tool.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1.0\n", string(data), "ASDF_INSTALL_VERSION is the original version")
+	assert.NoDirExists(t, filepath.Join(root, downloadDir, "tool", "1.1.0"))
+
+	err = a.Install(ctx, versions[2])
+	assert.ErrorContains(t, err, "failed to install version 2.0.0-rc.1")
+	assert.NoDirExists(t, filepath.Join(root, "tool", "2.0.0-rc.1"))
+
+	// exec-env 以默认版本的原始版本号和安装目录执行，安装目录替换为 current
+	t.Setenv("HOME", t.TempDir())
+	require.NoError(t, a.SetDefaultVersion(ctx, "1.1.0"))
+	current := filepath.Join(root, "tool", "current")
+	envs := a.Envs()
+	require.Len(t, envs, 4)
+	assert.Equal(t, filepath.Join(current, "libexec"), envs[0].Value)
+	assert.Equal(t, filepath.Join(current, "shims"), envs[1].Value)
+	assert.Equal(t, env.KV{Key: "TOOL_HOME", Value: current}, envs[2])
+	assert.Equal(t, env.KV{Key: "TOOL_VERSION", Value: "v1.1.0"}, envs[3])
+
+	require.NoError(t, a.Uninstall(ctx, "1.1.0"))
+	assert.NoDirExists(t, filepath.Join(root, "tool", "1.1.0"))
+	data, err = os.ReadFile(filepath.Join(root, "tool", "uninstalled.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1.0\n", string(data), "bin/uninstall receives the same version as bin/install")
+	assert.NoFileExists(t, filepath.Join(root, originDir, "tool", "1.1.0"))
+}
+
+func TestAsdfGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil || runtime.GOOS == "windows" {
+		t.Skip("git is required")
+	}
+	root := testutil.SetRootDir(t)
+
+	repo := t.TempDir()
+	fakePlugin(t, repo)
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=gvm", "-c", "user.email=gvm@example.com", "commit", "-qm", "init"},
+	} {
+		out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	a, err := NewAsdf("tool", "file://"+repo)
+	require.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(root, pluginDir, "tool"), "cloned on first use")
+	versions, err := a.ListRemoteVersions(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, versions, 3)
+	assert.FileExists(t, filepath.Join(root, pluginDir, "tool", "bin", "list-all"))
+}
+
+func TestNewAsdf(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("asdf plugins are not supported on windows")
+	}
+	_, err := NewAsdf("tool", t.TempDir())
+	assert.ErrorContains(t, err, "bin/list-all is missing")
+	_, err = NewAsdf("tool", "asdf-tool")
+	assert.ErrorContains(t, err, "must be an absolute path")
+	_, err = NewAsdf("tool", "")
+	assert.ErrorContains(t, err, "invalid data source name")
+	a, err := NewAsdf("tool", "https://github.com/asdf-community/asdf-tool.git")
+	require.NoError(t, err)
+	assert.Equal(t, "tool", a.Executable())
+}
@@ -0,0 +1,476 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package declarative 从 languages.d 目录中的 YAML/JSON 定义文件创建语言，
+// 适用于提供 JSON 版本索引和固定下载地址的工具，如 Zig、Terraform
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/http"
+	"github.com/toodofun/gvm/internal/log"
+	"github.com/toodofun/gvm/internal/util/asset"
+	"github.com/toodofun/gvm/internal/util/env"
+	"github.com/toodofun/gvm/internal/util/path"
+	"github.com/toodofun/gvm/languages"
+
+	"github.com/duke-git/lancet/v2/maputil"
+	"github.com/duke-git/lancet/v2/slice"
+	goversion "github.com/hashicorp/go-version"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Dir 定义文件所在的目录，位于 gvm 根目录下
+	Dir = "languages.d"
+	// keyField 索引为对象时，以键作为版本号
+	keyField = "$key"
+)
+
+// archives 支持的安装包格式
+var archives = []string{"tar.gz", "tgz", "tar.xz", "txz", "tar.bz2", "tbz2", "zip", asset.FormatRaw}
+
+// Index 版本索引及字段映射，字段路径用 . 分隔，如 versions、builds.0.url
+type Index struct {
+	// URL 返回 JSON 的索引地址
+	URL string `yaml:"url"`
+	// Versions 版本列表在索引中的路径，为空时索引本身就是列表或以版本号为键的对象
+	Versions string `yaml:"versions"`
+	// Version 版本号字段，列表默认为 version，对象默认为 $key
+	Version string `yaml:"version"`
+	// Comment 备注字段，字符串直接作为备注，布尔值为 true 时使用字段名
+	Comment string `yaml:"comment"`
+	// Prerelease 标记预发布版本的布尔字段
+	Prerelease string `yaml:"prerelease"`
+}
+
+// Definition 语言定义，url 与 url_field 中可以使用 {version}、{origin}、{os}、{arch}、{ext} 占位符，
+// env 中可以使用 {current} 表示当前版本的目录
+type Definition struct {
+	Name  string `yaml:"name"`
+	Index Index  `yaml:"index"`
+	// URL 下载地址模板
+	URL string `yaml:"url"`
+	// URLField 版本条目中下载地址的字段路径，与 URL 二选一，如 {arch}-{os}.tarball
+	URLField string `yaml:"url_field"`
+	// OS、Arch 将 GOOS、GOARCH 映射为下载地址中的写法，如 darwin: macos
+	OS   map[string]string `yaml:"os"`
+	Arch map[string]string `yaml:"arch"`
+	// Archive 安装包格式，ArchiveOS 按系统覆盖，如 windows: zip
+	Archive   string            `yaml:"archive"`
+	ArchiveOS map[string]string `yaml:"archive_os"`
+	// Strip 去掉压缩包中的顶层目录层数
+	Strip      int    `yaml:"strip"`
+	Executable string `yaml:"executable"`
+	// BinDirs 相对版本目录加入 PATH 的目录，默认为 bin，空字符串表示版本目录本身
+	BinDirs []string          `yaml:"bin_dirs"`
+	Env     map[string]string `yaml:"env"`
+}
+
+// Validate 检查定义是否完整，并补充默认值
+func (d *Definition) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if err := core.ValidateName(d.Name); err != nil {
+		return err
+	}
+	if d.Index.URL == "" {
+		return fmt.Errorf("index.url is required")
+	}
+	if d.URL == "" && d.URLField == "" {
+		return fmt.Errorf("one of url and url_field is required")
+	}
+	if d.Archive == "" {
+		d.Archive = "tar.gz"
+	}
+	for _, a := range append([]string{d.Archive}, maputil.Values(d.ArchiveOS)...) {
+		if !slice.Contain(archives, a) {
+			return fmt.Errorf("unsupported archive %s, supported: %s", a, strings.Join(archives, ", "))
+		}
+	}
+	if d.Strip < 0 {
+		return fmt.Errorf("invalid strip %d, expected a non-negative number", d.Strip)
+	}
+	if d.Executable == "" {
+		d.Executable = d.Name
+	}
+	if d.BinDirs == nil {
+		d.BinDirs = []string{"bin"}
+	}
+	return nil
+}
+
+// Parse 解析定义文件，JSON 是 YAML 的子集，两种格式使用同一个解析器，未知字段视为错误
+func Parse(data []byte) (*Definition, error) {
+	d := new(Definition)
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(d); err != nil {
+		return nil, err
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Language 由定义文件描述的语言
+type Language struct {
+	def *Definition
+}
+
+// NewLanguage 根据定义创建语言
+func NewLanguage(def *Definition) *Language {
+	return &Language{def: def}
+}
+
+func (l *Language) Name() string {
+	return l.def.Name
+}
+
+func (l *Language) Executable() string {
+	return l.def.Executable
+}
+
+// entry 索引中的一个版本
+type entry struct {
+	origin string
+	value  any
+}
+
+// entries 获取索引并返回所有版本条目，索引请求会被 http 客户端缓存
+func (l *Language) entries(ctx context.Context) ([]entry, error) {
+	body, err := http.Default().Get(ctx, l.def.Index.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index of %s: %w", l.Name(), err)
+	}
+	var index any
+	if err := json.Unmarshal(body, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse index of %s: %w", l.Name(), err)
+	}
+	node, ok := lookup(index, l.def.Index.Versions)
+	if !ok {
+		return nil, fmt.Errorf("versions %q not found in index of %s", l.def.Index.Versions, l.Name())
+	}
+
+	res := make([]entry, 0)
+	switch v := node.(type) {
+	case []any:
+		field := l.def.Index.Version
+		if field == "" {
+			field = "version"
+		}
+		for _, item := range v {
+			if origin, ok := lookup(item, field); ok {
+				res = append(res, entry{origin: fmt.Sprint(origin), value: item})
+			}
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			origin := any(k)
+			if field := l.def.Index.Version; field != "" && field != keyField {
+				if origin, ok = lookup(v[k], field); !ok {
+					continue
+				}
+			}
+			res = append(res, entry{origin: fmt.Sprint(origin), value: v[k]})
+		}
+	default:
+		return nil, fmt.Errorf("versions %q in index of %s is neither a list nor an object", l.def.Index.Versions, l.Name())
+	}
+	return res, nil
+}
+
+func (l *Language) ListRemoteVersions(ctx context.Context) ([]*core.RemoteVersion, error) {
+	logger := log.GetLogger(ctx)
+	entries, err := l.entries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*core.RemoteVersion, 0, len(entries))
+	for _, e := range entries {
+		// 索引中可能有 master、nightly 等不是版本号的条目
+		ver, err := goversion.NewVersion(strings.TrimPrefix(e.origin, "v"))
+		if err != nil {
+			logger.Debugf("Skip %s of %s: %v", e.origin, l.Name(), err)
+			continue
+		}
+		comment := "Stable Release"
+		if prerelease, ok := lookup(e.value, l.def.Index.Prerelease); ok && l.def.Index.Prerelease != "" && prerelease == true {
+			comment = "Prerelease"
+		}
+		if l.def.Index.Comment != "" {
+			switch c, _ := lookup(e.value, l.def.Index.Comment); c := c.(type) {
+			case string:
+				if c != "" {
+					comment = c
+				}
+			case bool:
+				if c {
+					comment = l.def.Index.Comment[strings.LastIndex(l.def.Index.Comment, ".")+1:]
+				}
+			}
+		}
+		res = append(res, &core.RemoteVersion{
+			Version: ver,
+			Origin:  e.origin,
+			Comment: comment,
+		})
+	}
+	return res, nil
+}
+
+// archive 返回当前系统使用的安装包格式
+func (l *Language) archive() string {
+	if a, ok := l.def.ArchiveOS[runtime.GOOS]; ok {
+		return a
+	}
+	return l.def.Archive
+}
+
+func (l *Language) expand(template string, rv *core.RemoteVersion) string {
+	goos, goarch := runtime.GOOS, runtime.GOARCH
+	if v, ok := l.def.OS[goos]; ok {
+		goos = v
+	}
+	if v, ok := l.def.Arch[goarch]; ok {
+		goarch = v
+	}
+	return strings.NewReplacer(
+		"{version}", rv.Version.String(),
+		"{origin}", rv.Origin,
+		"{os}", goos,
+		"{arch}", goarch,
+		"{ext}", l.archive(),
+	).Replace(template)
+}
+
+// ResolveAsset 按定义中的模板或字段得到当前平台的下载地址
+func (l *Language) ResolveAsset(
+	ctx context.Context,
+	remoteVersion *core.RemoteVersion,
+) (*core.Asset, []core.AssetRejection, error) {
+	url := ""
+	if l.def.URLField == "" {
+		url = l.expand(l.def.URL, remoteVersion)
+	} else {
+		entries, err := l.entries(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		field := l.expand(l.def.URLField, remoteVersion)
+		for _, e := range entries {
+			if e.origin != remoteVersion.Origin {
+				continue
+			}
+			if v, ok := lookup(e.value, field); ok {
+				url = fmt.Sprint(v)
+			}
+		}
+		if url == "" {
+			return nil, nil, fmt.Errorf("%s of %s not found in index, %s/%s may not be supported",
+				field, remoteVersion.Origin, runtime.GOOS, runtime.GOARCH)
+		}
+	}
+	return &core.Asset{Name: l.filename(remoteVersion), URL: url}, nil, nil
+}
+
+// filename 下载保存的文件名，Layout 根据扩展名判断解压方式
+func (l *Language) filename(remoteVersion *core.RemoteVersion) string {
+	if l.archive() == asset.FormatRaw {
+		if runtime.GOOS == env.RuntimeFromWindows {
+			return l.Executable() + ".exe"
+		}
+		return l.Executable()
+	}
+	return fmt.Sprintf("%s-%s.%s", l.Name(), remoteVersion.Version.String(), l.archive())
+}
+
+func (l *Language) layout() *asset.Layout {
+	return &asset.Layout{Strip: l.def.Strip}
+}
+
+func (l *Language) Install(ctx context.Context, remoteVersion *core.RemoteVersion) error {
+	logger := log.GetLogger(ctx)
+	logger.Infof("Install remote version %s", remoteVersion.Origin)
+	if err, exist := languages.HasInstall(ctx, l, *remoteVersion.Version); err != nil || exist {
+		return err
+	}
+
+	selected, _, err := l.ResolveAsset(ctx, remoteVersion)
+	if err != nil {
+		return err
+	}
+	head, code, err := http.Default().Head(ctx, selected.URL)
+	if err != nil {
+		return err
+	}
+	if code != 200 {
+		return fmt.Errorf("version %s not found at %s, status code: %d", remoteVersion.Origin, selected.URL, code)
+	}
+
+	dest := filepath.Join(path.GetLangRoot(l.Name()), remoteVersion.Version.String())
+	logger.Infof("Downloading %s size: %s", selected.URL, head.Get("Content-Length"))
+	file, err := http.Default().Download(ctx, selected.URL, dest, selected.Name)
+	logger.Infof("")
+	if err != nil {
+		l.clean(ctx, dest)
+		return fmt.Errorf("failed to download version %s: %w", remoteVersion.Version.String(), err)
+	}
+	if err := l.layout().Install(ctx, file, dest, l.Executable()); err != nil {
+		l.clean(ctx, dest)
+		return fmt.Errorf("failed to extract version %s: %w", remoteVersion.Version.String(), err)
+	}
+	if err = os.RemoveAll(file); err != nil {
+		logger.Warnf("Failed to clean %s: %v", file, err)
+	}
+	logger.Infof("Version %s was successfully installed in %s", remoteVersion.Version.String(), dest)
+	return nil
+}
+
+// clean 删除下载或解压失败的版本目录，否则下次安装会认为该版本已经安装
+func (l *Language) clean(ctx context.Context, dest string) {
+	if err := os.RemoveAll(dest); err != nil {
+		log.GetLogger(ctx).Warnf("Failed to clean %s: %v", dest, err)
+	}
+}
+
+func (l *Language) InstallFromFile(ctx context.Context, remoteVersion *core.RemoteVersion, file string) error {
+	if err, exist := languages.HasInstall(ctx, l, *remoteVersion.Version); err != nil || exist {
+		return err
+	}
+	dest := filepath.Join(path.GetLangRoot(l.Name()), remoteVersion.Version.String())
+	if err := l.layout().Install(ctx, file, dest, l.Executable()); err != nil {
+		l.clean(ctx, dest)
+		return fmt.Errorf("failed to extract version %s: %w", remoteVersion.Version.String(), err)
+	}
+	log.GetLogger(ctx).Infof("Version %s was successfully installed in %s", remoteVersion.Version.String(), dest)
+	return nil
+}
+
+func (l *Language) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVersion, error) {
+	return languages.NewLanguage(l).ListInstalledVersions(ctx, "")
+}
+
+// Envs 将 bin_dirs 加入 PATH，并设置 env 中声明的变量
+func (l *Language) Envs() []env.KV {
+	current := filepath.Join(path.GetLangRoot(l.Name()), path.Current)
+	res := make([]env.KV, 0, len(l.def.BinDirs)+len(l.def.Env))
+	for _, dir := range l.def.BinDirs {
+		res = append(res, env.KV{Key: "PATH", Value: filepath.Join(current, dir), Append: true})
+	}
+	keys := make([]string, 0, len(l.def.Env))
+	for k := range l.def.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		res = append(res, env.KV{Key: k, Value: strings.ReplaceAll(l.def.Env[k], "{current}", current)})
+	}
+	return res
+}
+
+func (l *Language) SetDefaultVersion(ctx context.Context, version string) error {
+	return languages.NewLanguage(l).SetDefaultVersion(ctx, version, l.Envs())
+}
+
+func (l *Language) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
+	return languages.NewLanguage(l).GetDefaultVersion()
+}
+
+func (l *Language) Uninstall(ctx context.Context, version string) error {
+	return languages.NewLanguage(l).Uninstall(ctx, version, l.Envs())
+}
+
+func (l *Language) UnsetDefaultVersion(ctx context.Context) error {
+	return languages.NewLanguage(l).UnsetDefaultVersion(ctx, l.Envs())
+}
+
+// lookup 按 . 分隔的路径读取 JSON 中的值，列表使用下标，路径为空时返回 node 本身
+func lookup(node any, field string) (any, bool) {
+	if field == "" {
+		return node, true
+	}
+	for _, key := range strings.Split(field, ".") {
+		switch v := node.(type) {
+		case map[string]any:
+			next, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			node = next
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			node = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+var loadErrors = make(map[string]error)
+
+// Load 解析 languages.d 下的 yaml 和 json 文件并注册为语言，按文件记录解析错误
+func Load() {
+	loadErrors = make(map[string]error)
+	files := make([]string, 0)
+	for _, pattern := range []string{"*.yaml", "*.yml", "*.json"} {
+		matches, _ := filepath.Glob(filepath.Join(core.GetRootDir(), Dir, pattern))
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			loadErrors[file] = err
+			continue
+		}
+		def, err := Parse(data)
+		if err != nil {
+			loadErrors[file] = err
+			continue
+		}
+		if _, exists := core.GetLanguage(def.Name); exists {
+			loadErrors[file] = fmt.Errorf("name %s conflicts with a registered language", def.Name)
+			continue
+		}
+		core.RegisterLanguage(NewLanguage(def))
+	}
+}
+
+// LoadErrors 返回最近一次 Load 中加载失败的定义文件及原因
+func LoadErrors() map[string]error {
+	return loadErrors
+}
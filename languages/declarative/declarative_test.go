@@ -0,0 +1,163 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package declarative
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/testutil"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// newFakeIndex 模拟 Zig 风格的索引：以版本号为键，下载地址在 <arch>-<os>.tarball 字段中
+func newFakeIndex(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	platform := fmt.Sprintf("%s-%s", runtime.GOARCH, runtime.GOOS)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.json":
+			_, _ = fmt.Fprintf(w, `{
+				"master": {"%[1]s": {"tarball": "%[2]s/dl/master.zip"}},
+				"0.2.0": {"lts": "LTS", "%[1]s": {"tarball": "%[2]s/dl/0.2.0.zip"}},
+				"0.1.0": {"%[1]s": {"tarball": "%[2]s/dl/0.1.0.zip"}}
+			}`, platform, server.URL)
+		case "/dl/0.2.0.zip":
+			zw := zip.NewWriter(w)
+			f, _ := zw.Create("tool-0.2.0/tool")
+			_, _ = f.Write([]byte("binary"))
+			_ = zw.Close()
+		case "/dl/0.1.0.zip":
+			_, _ = w.Write([]byte("broken"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return server
+}
+
+func TestLanguage(t *testing.T) {
+	root := testutil.SetRootDir(t)
+
+	server := newFakeIndex(t)
+	defer server.Close()
+	ctx := context.Background()
+
+	def, err := Parse([]byte(fmt.Sprintf(`
+name: tool
+index:
+  url: %s/index.json
+  comment: lts
+url_field: "{arch}-{os}.tarball"
+archive: zip
+strip: 1
+bin_dirs: [""]
+env:
+  TOOL_HOME: "{current}"
+`, server.URL)))
+	require.NoError(t, err)
+	l := NewLanguage(def)
+
+	versions, err := l.ListRemoteVersions(ctx)
+	require.NoError(t, err)
+	require.Len(t, versions, 2, "master is not a version")
+	assert.Equal(t, "0.1.0", versions[0].Origin)
+	assert.Equal(t, "Stable Release", versions[0].Comment)
+	assert.Equal(t, "LTS", versions[1].Comment)
+
+	selected, _, err := l.ResolveAsset(ctx, versions[1])
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/dl/0.2.0.zip", selected.URL)
+	assert.Equal(t, "tool-0.2.0.zip", selected.Name)
+
+	require.NoError(t, l.Install(ctx, versions[1]))
+	assert.FileExists(t, filepath.Join(root, "tool", "0.2.0", "tool"))
+	assert.NoFileExists(t, filepath.Join(root, "tool", "0.2.0", "tool-0.2.0.zip"))
+
+	// 解压失败时删除版本目录，否则下次安装会认为已经安装
+	assert.ErrorContains(t, l.Install(ctx, versions[0]), "failed to extract version 0.1.0")
+	assert.NoDirExists(t, filepath.Join(root, "tool", "0.1.0"))
+
+	envs := l.Envs()
+	require.Len(t, envs, 2)
+	assert.Equal(t, filepath.Join(root, "tool", "current"), envs[0].Value)
+	assert.Equal(t, "TOOL_HOME", envs[1].Key)
+	assert.Equal(t, filepath.Join(root, "tool", "current"), envs[1].Value)
+}
+
+func TestURLTemplate(t *testing.T) {
+	def, err := Parse([]byte(`{
+		"name": "terraform",
+		"index": {"url": "https://releases.hashicorp.com/terraform/index.json", "versions": "versions"},
+		"url": "https://releases.hashicorp.com/terraform/{version}/terraform_{version}_{os}_{arch}.{ext}",
+		"os": {"darwin": "macos"},
+		"arch": {"amd64": "x86_64", "arm64": "aarch64"},
+		"archive": "zip"
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bin"}, def.BinDirs)
+	assert.Equal(t, "terraform", def.Executable)
+
+	l := NewLanguage(def)
+	rv := &core.RemoteVersion{Version: goversion.Must(goversion.NewVersion("1.5.0")), Origin: "v1.5.0"}
+	def.OS = map[string]string{runtime.GOOS: "myos"}
+	def.Arch = map[string]string{runtime.GOARCH: "myarch"}
+	assert.Equal(t, "v1.5.0-1.5.0-myos-myarch.zip", l.expand("{origin}-{version}-{os}-{arch}.{ext}", rv))
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse([]byte("name: tool\nindex: {url: x}\n"))
+	assert.ErrorContains(t, err, "one of url and url_field is required")
+	_, err = Parse([]byte("name: tool\nindex: {url: x}\nurl: y\narchive: rar\n"))
+	assert.ErrorContains(t, err, "unsupported archive rar")
+	_, err = Parse([]byte("name: tool\nindex: {url: x}\nurl: y\nbins: [bin]\n"))
+	assert.ErrorContains(t, err, "field bins not found")
+	_, err = Parse([]byte("name: ../tool\nindex: {url: x}\nurl: y\n"))
+	assert.ErrorContains(t, err, "invalid name")
+}
+
+func TestLoad(t *testing.T) {
+	root := testutil.SetRootDir(t)
+
+	dir := filepath.Join(root, Dir)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "good.yaml"),
+		[]byte("name: declarative-test\nindex: {url: x}\nurl: y\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.json"), []byte(`{"name": "bad"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644))
+
+	Load()
+	_, exists := core.GetLanguage("declarative-test")
+	assert.True(t, exists)
+	assert.Len(t, LoadErrors(), 1)
+	assert.ErrorContains(t, LoadErrors()[filepath.Join(dir, "bad.json")], "index.url is required")
+
+	// 再次加载时名称与已注册的语言冲突
+	Load()
+	assert.ErrorContains(t, LoadErrors()[filepath.Join(dir, "good.yaml")], "conflicts with a registered language")
+}
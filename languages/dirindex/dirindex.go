@@ -0,0 +1,230 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dirindex 解析 Apache、nginx 等服务器生成的 HTML 目录索引，
+// 既可以被内置语言使用，也可以通过 gvm add dirindex 作为插件使用
+package dirindex
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/http"
+	"github.com/toodofun/gvm/internal/util/asset"
+	"github.com/toodofun/gvm/languages/release"
+)
+
+var (
+	href = regexp.MustCompile(`(?i)<a\s[^>]*href\s*=\s*["']([^"']+)["']`)
+	// DefaultVersion 默认的版本正则，匹配 1.2.3/、v1.2/ 这样的版本目录
+	DefaultVersion = regexp.MustCompile(`^v?(\d+(?:\.\d+)+)/$`)
+)
+
+// Links 返回目录页面中直接位于该目录下的条目名称，子目录以 / 结尾；
+// 跳过上级目录、排序链接和指向其他位置的链接
+func Links(ctx context.Context, dir string) ([]string, error) {
+	dir = strings.TrimSuffix(dir, "/") + "/"
+	base, err := url.Parse(dir)
+	if err != nil {
+		return nil, err
+	}
+	body, err := http.Default().Get(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get directory index %s: %w", dir, err)
+	}
+
+	res := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, m := range href.FindAllStringSubmatch(string(body), -1) {
+		ref, err := url.Parse(m[1])
+		if err != nil {
+			continue
+		}
+		target := base.ResolveReference(ref)
+		if target.Host != base.Host || target.RawQuery != "" || !strings.HasPrefix(target.Path, base.Path) {
+			continue
+		}
+		name := strings.TrimPrefix(target.Path, base.Path)
+		if name == "" || strings.Contains(strings.TrimSuffix(name, "/"), "/") || seen[name] {
+			continue
+		}
+		seen[name] = true
+		res = append(res, name)
+	}
+	return res, nil
+}
+
+// Version 目录索引中的一个版本，Dir 不为空时安装包位于该子目录中，否则为根目录中的 Files
+type Version struct {
+	Name  string
+	Dir   string
+	Files []string
+}
+
+// Index HTML 目录索引，Pattern 匹配根目录中的条目，第一个分组为版本号（没有分组时为整个匹配）；
+// 匹配到子目录时版本的安装包在子目录中，匹配到文件时该文件就是版本的安装包
+type Index struct {
+	URL     string
+	Pattern *regexp.Regexp
+}
+
+// Versions 按页面中的顺序返回所有版本
+func (i *Index) Versions(ctx context.Context) ([]*Version, error) {
+	links, err := Links(ctx, i.URL)
+	if err != nil {
+		return nil, err
+	}
+	pattern := i.Pattern
+	if pattern == nil {
+		pattern = DefaultVersion
+	}
+
+	res := make([]*Version, 0)
+	versions := make(map[string]*Version)
+	for _, link := range links {
+		m := pattern.FindStringSubmatch(link)
+		if m == nil {
+			continue
+		}
+		name := m[len(m)-1]
+		if len(m) > 1 {
+			name = m[1]
+		}
+		v, ok := versions[name]
+		if !ok {
+			v = &Version{Name: name}
+			versions[name] = v
+			res = append(res, v)
+		}
+		if strings.HasSuffix(link, "/") {
+			v.Dir = link
+		} else {
+			v.Files = append(v.Files, link)
+		}
+	}
+	return res, nil
+}
+
+// Files 返回版本的安装包名称
+func (i *Index) Files(ctx context.Context, v *Version) ([]string, error) {
+	if v.Dir == "" {
+		return v.Files, nil
+	}
+	links, err := Links(ctx, i.URL+v.Dir)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(links))
+	for _, link := range links {
+		if !strings.HasSuffix(link, "/") {
+			res = append(res, link)
+		}
+	}
+	return res, nil
+}
+
+// FileURL 返回安装包的下载地址
+func (i *Index) FileURL(v *Version, file string) string {
+	return i.URL + v.Dir + url.PathEscape(file)
+}
+
+// Dirindex 使用 HTML 目录索引作为数据源的插件
+type Dirindex struct {
+	*release.Addon
+	index *Index
+}
+
+// ListReleases 返回目录索引中的版本，位于子目录中的安装包在 ListAssets 中获取，避免列出版本时逐个请求
+func (d *Dirindex) ListReleases(ctx context.Context) ([]*release.Release, error) {
+	versions, err := d.index.Versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*release.Release, 0, len(versions))
+	for _, v := range versions {
+		item := &release.Release{Name: v.Name}
+		for _, f := range v.Files {
+			item.Assets = append(item.Assets, core.Asset{Name: f, URL: d.index.FileURL(v, f)})
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
+
+// ListAssets 获取版本子目录中的安装包
+func (d *Dirindex) ListAssets(ctx context.Context, r *release.Release) ([]core.Asset, error) {
+	versions, err := d.index.Versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.Name != r.Name {
+			continue
+		}
+		files, err := d.index.Files(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]core.Asset, 0, len(files))
+		for _, f := range files {
+			res = append(res, core.Asset{Name: f, URL: d.index.FileURL(v, f)})
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("version %s not found in %s", r.Name, d.index.URL)
+}
+
+// NewDirindex 创建目录索引插件，数据源格式为 <url>[?version=<regex>&<asset rules>]，
+// version 为匹配版本目录或安装包的正则，默认匹配 1.2.3/ 这样的目录
+func NewDirindex(name, dsn string) (*Dirindex, error) {
+	base, query, _ := strings.Cut(dsn, "?")
+	if !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+		return nil, fmt.Errorf("invalid data source name: %s, expected format: <url>[?version=<regex>&<asset rules>]", dsn)
+	}
+	index := &Index{URL: strings.TrimSuffix(base, "/") + "/", Pattern: DefaultVersion}
+
+	rules := make([]string, 0)
+	for _, pair := range strings.Split(query, "&") {
+		value, ok := strings.CutPrefix(pair, "version=")
+		if !ok {
+			rules = append(rules, pair)
+			continue
+		}
+		value, err := url.PathUnescape(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version regex %s: %w", value, err)
+		}
+		if index.Pattern, err = regexp.Compile(value); err != nil {
+			return nil, fmt.Errorf("invalid version regex %s: %w", value, err)
+		}
+	}
+	assetRules, err := asset.ParseRules(strings.Join(rules, "&"))
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Dirindex{index: index}
+	d.Addon = release.NewAddon(name, name, d, assetRules)
+	return d, nil
+}
+
+func init() {
+	core.RegisterAddonProvider("dirindex", func(name, dsn string) (core.Language, error) {
+		return NewDirindex(name, dsn)
+	})
+}
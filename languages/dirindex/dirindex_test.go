@@ -0,0 +1,128 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dirindex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/testutil"
+)
+
+// listing 生成 Apache 风格的目录页面，包含排序链接和上级目录
+func listing(names ...string) string {
+	res := `<html><body><h1>Index</h1><a href="?C=N;O=D">Name</a> <a href="../">Parent Directory</a>` + "\n"
+	for _, n := range names {
+		res += fmt.Sprintf(`<a href="%s">%s</a>`+"\n", n, n)
+	}
+	return res + `<a href="https://example.com/other/">elsewhere</a></body></html>`
+}
+
+// newFakeServer 模拟两种布局：/nested/ 下每个版本一个目录，/flat/ 下所有安装包位于同一目录
+func newFakeServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	asset := fmt.Sprintf("mytool-1.2.0-%s-%s", runtime.GOOS, runtime.GOARCH)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/nested/":
+			_, _ = fmt.Fprint(w, listing("1.1.0/", "1.2.0/", "latest/", "README.txt"))
+		case "/nested/1.2.0/":
+			_, _ = fmt.Fprint(w, listing(asset, "mytool-1.2.0-plan9-amd64", "checksums.txt"))
+		case "/nested/1.2.0/" + asset:
+			_, _ = fmt.Fprint(w, "binary")
+		case "/flat/":
+			_, _ = fmt.Fprint(w, listing("tool-1.0.0-linux.tar.gz", "tool-1.0.0-darwin.tar.gz", "tool-1.1.0-linux.tar.gz", "sub/"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestIndex(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.Close()
+	ctx := context.Background()
+
+	links, err := Links(ctx, server.URL+"/nested")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1.1.0/", "1.2.0/", "latest/", "README.txt"}, links)
+
+	index := &Index{URL: server.URL + "/nested/"}
+	versions, err := index.Versions(ctx)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, &Version{Name: "1.2.0", Dir: "1.2.0/"}, versions[1])
+	files, err := index.Files(ctx, versions[1])
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+
+	index = &Index{URL: server.URL + "/flat/", Pattern: regexp.MustCompile(`^tool-(\d+\.\d+\.\d+)-`)}
+	versions, err = index.Versions(ctx)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, []string{"tool-1.0.0-linux.tar.gz", "tool-1.0.0-darwin.tar.gz"}, versions[0].Files)
+	assert.Equal(t, server.URL+"/flat/tool-1.1.0-linux.tar.gz", index.FileURL(versions[1], versions[1].Files[0]))
+}
+
+func TestDirindex(t *testing.T) {
+	root := testutil.SetRootDir(t)
+
+	server := newFakeServer(t)
+	defer server.Close()
+	ctx := context.Background()
+
+	d, err := NewDirindex("mytool", server.URL+"/nested/?formats=raw")
+	require.NoError(t, err)
+	versions, err := d.ListRemoteVersions(ctx)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+
+	selected, _, err := d.ResolveAsset(ctx, versions[1])
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%s/nested/1.2.0/mytool-1.2.0-%s-%s", server.URL, runtime.GOOS, runtime.GOARCH), selected.URL)
+
+	require.NoError(t, d.Install(ctx, versions[1]))
+	name := "mytool"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	data, err := os.ReadFile(filepath.Join(root, "mytool", "1.2.0", "bin", name))
+	require.NoError(t, err)
+	assert.Equal(t, "binary", string(data))
+}
+
+func TestNewDirindex(t *testing.T) {
+	d, err := NewDirindex("tool", `https://example.com/tool?version=^tool-(\d+\.\d+)-&strip=1`)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/tool/", d.index.URL)
+	assert.Equal(t, `^tool-(\d+\.\d+)-`, d.index.Pattern.String())
+
+	_, err = NewDirindex("tool", "example.com/tool")
+	assert.ErrorContains(t, err, "invalid data source name")
+	_, err = NewDirindex("tool", "https://example.com/tool?version=(")
+	assert.ErrorContains(t, err, "invalid version regex")
+	_, err = NewDirindex("tool", "https://example.com/tool?unknown=1")
+	assert.ErrorContains(t, err, "unknown asset rule")
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/http"
+	"github.com/toodofun/gvm/internal/util/asset"
+	"github.com/toodofun/gvm/languages/release"
+)
+
+const (
+	defaultURL = "https://gitea.com"
+	pageSize   = 50
+)
+
+// Release Gitea Releases API 返回的发布版本，Forgejo 的接口与之相同
+type Release struct {
+	Name       string  `json:"name"`
+	TagName    string  `json:"tag_name"`
+	Draft      bool    `json:"draft"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset 发布版本中的附件
+type Asset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+}
+
+// Gitea 使用 Gitea 或 Forgejo Releases 作为数据源的插件
+type Gitea struct {
+	*release.Addon
+	baseURL string
+	owner   string
+	repo    string
+}
+
+// instance Gitea 实例，地址依次读取 GVM_GITEA_URL、配置 gitea.url，默认为 gitea.com；
+// token 依次读取 GITEA_TOKEN 和配置 gitea.token
+var instance = &release.Instance{
+	DefaultURL: defaultURL,
+	URLEnv:     "GVM_GITEA_URL",
+	TokenEnv:   "GITEA_TOKEN",
+	Config: func(config *core.Config) (string, string) {
+		if config.Gitea == nil {
+			return "", ""
+		}
+		return config.Gitea.URL, config.Gitea.Token
+	},
+	Header: "Authorization",
+	Scheme: "token",
+}
+
+// Headers 为 API 请求和附件下载提供 token，私有仓库的附件同样需要认证
+func (g *Gitea) Headers(url string) map[string]string {
+	return instance.Headers(g.baseURL, url)
+}
+
+// ListReleases 分页获取仓库的所有发布版本，跳过草稿，tag 作为版本名称
+func (g *Gitea) ListReleases(ctx context.Context) ([]*release.Release, error) {
+	res := make([]*release.Release, 0)
+	for page := 1; ; page++ {
+		api := fmt.Sprintf("%s/api/v1/repos/%s/%s/releases?page=%d&limit=%d",
+			g.baseURL, url.PathEscape(g.owner), url.PathEscape(g.repo), page, pageSize)
+		body, respHeader, err := http.Default().GetWithHeader(ctx, api, g.Headers(api))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get releases of %s/%s: %w", g.owner, g.repo, err)
+		}
+		releases := make([]Release, 0)
+		if err := json.Unmarshal(body, &releases); err != nil {
+			return nil, fmt.Errorf("failed to parse releases of %s/%s: %w", g.owner, g.repo, err)
+		}
+		for _, r := range releases {
+			if r.Draft {
+				continue
+			}
+			item := &release.Release{Name: r.TagName, Prerelease: r.Prerelease}
+			for _, a := range r.Assets {
+				item.Assets = append(item.Assets, core.Asset{Name: a.Name, URL: a.DownloadURL})
+			}
+			res = append(res, item)
+		}
+		// 旧版本 Gitea 不返回 Link，此时以不满一页作为结束
+		if len(releases) == 0 || len(releases) < pageSize && !strings.Contains(respHeader.Get("Link"), `rel="next"`) {
+			break
+		}
+	}
+	return res, nil
+}
+
+// NewGitea 创建 Gitea 插件，数据源格式为 [https://<host>/]<owner>/<repo>[?<asset rules>]，
+// 不写实例地址时使用 instance 的地址
+func NewGitea(name, dsn string) (*Gitea, error) {
+	repository, query, _ := strings.Cut(dsn, "?")
+	baseURL := instance.URL()
+	if strings.HasPrefix(repository, "http://") || strings.HasPrefix(repository, "https://") {
+		u, err := url.Parse(repository)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data source name: %s: %w", dsn, err)
+		}
+		// 实例可能部署在子路径下，如 https://example.com/git/org/repo
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(segments) < 2 {
+			return nil, fmt.Errorf("invalid data source name: %s, expected format: [https://<host>/]<owner>/<repo>[?<asset rules>]", dsn)
+		}
+		prefix := strings.Join(segments[:len(segments)-2], "/")
+		baseURL = strings.TrimSuffix(u.Scheme+"://"+u.Host+"/"+prefix, "/")
+		repository = strings.Join(segments[len(segments)-2:], "/")
+	}
+	parts := strings.Split(strings.Trim(repository, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid data source name: %s, expected format: [https://<host>/]<owner>/<repo>[?<asset rules>]", dsn)
+	}
+	rules, err := asset.ParseRules(query)
+	if err != nil {
+		return nil, err
+	}
+	g := &Gitea{
+		baseURL: baseURL,
+		owner:   parts[0],
+		repo:    parts[1],
+	}
+	g.Addon = release.NewAddon(name, parts[1], g, rules)
+	return g, nil
+}
+
+func init() {
+	core.RegisterAddonProvider("gitea", func(name, dsn string) (core.Language, error) {
+		return NewGitea(name, dsn)
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitea
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/testutil"
+)
+
+// newFakeGitea 模拟 Gitea Releases API，第一页通过 Link 指向第二页
+func newFakeGitea(t *testing.T, prefix string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	asset := fmt.Sprintf("mytool_%s_%s.zip", runtime.GOOS, runtime.GOARCH)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case prefix + "/api/v1/repos/org/repo/releases":
+			assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+			var releases []Release
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2>; rel="next"`, server.URL, r.URL.Path))
+				releases = []Release{
+					{TagName: "v2.0.0", Draft: true},
+					{TagName: "v1.1.0", Assets: []Asset{{Name: asset, DownloadURL: server.URL + "/dl/" + asset}}},
+				}
+			} else {
+				releases = []Release{{TagName: "v1.0.0-rc.1", Prerelease: true}}
+			}
+			_ = json.NewEncoder(w).Encode(releases)
+		case "/dl/" + asset:
+			// 私有仓库的附件同样需要 token
+			if r.Header.Get("Authorization") != "token secret" {
+				http.NotFound(w, r)
+				return
+			}
+			zw := zip.NewWriter(w)
+			f, _ := zw.Create("mytool-1.1.0/mytool")
+			_, _ = f.Write([]byte("binary"))
+			_ = zw.Close()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return server
+}
+
+func TestGitea(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+	root := testutil.SetRootDir(t)
+
+	server := newFakeGitea(t, "/git")
+	defer server.Close()
+	// 与 gvm add gitea mytool https://git.example.com/org/repo 相同，只设置了 GITEA_TOKEN
+	t.Setenv("GVM_GITEA_URL", "")
+	t.Setenv("GITEA_TOKEN", "secret")
+	ctx := context.Background()
+
+	g, err := NewGitea("mytool", server.URL+"/git/org/repo?strip=1&bin=mytool")
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/git", g.baseURL)
+
+	versions, err := g.ListRemoteVersions(ctx)
+	require.NoError(t, err)
+	require.Len(t, versions, 2, "drafts are skipped")
+	assert.Equal(t, "v1.1.0", versions[0].Origin)
+	assert.Equal(t, "Prerelease", versions[1].Comment)
+
+	require.NoError(t, g.Install(ctx, versions[0]))
+	assert.FileExists(t, filepath.Join(root, "mytool", "1.1.0", "mytool"))
+	target, err := os.Readlink(filepath.Join(root, "mytool", "1.1.0", "bin", "mytool"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("..", "mytool"), target)
+}
+
+func TestNewGitea(t *testing.T) {
+	t.Setenv("GVM_GITEA_URL", "https://codeberg.org/")
+	g, err := NewGitea("tool", "org/repo")
+	require.NoError(t, err)
+	assert.Equal(t, "https://codeberg.org", g.baseURL)
+
+	g, err = NewGitea("tool", "https://git.example.com/org/repo")
+	require.NoError(t, err)
+	assert.Equal(t, "https://git.example.com", g.baseURL)
+	assert.Equal(t, "repo", g.Executable())
+
+	_, err = NewGitea("tool", "https://git.example.com/repo")
+	assert.ErrorContains(t, err, "invalid data source name")
+	_, err = NewGitea("tool", "a/b/c")
+	assert.ErrorContains(t, err, "invalid data source name")
+}
@@ -18,10 +18,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"strings"
-	"time"
+
+	"github.com/toodofun/gvm/internal/http"
 )
 
 type Release struct {
@@ -37,17 +36,15 @@ type Assets struct {
 
 // Client GitHub API 客户端
 type Client struct {
-	httpClient *http.Client
-	token      string
+	baseURL string
+	token   string
 }
 
-// NewGitHubClient 创建新的 GitHub 客户端
+// NewGitHubClient 创建新的 GitHub 客户端，token 为空时使用 http.GitHubToken，API 地址见 http.GitHubAPIURL
 func NewGitHubClient(token string) *Client {
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		token: token,
+		baseURL: http.GitHubAPIURL(),
+		token:   token,
 	}
 }
 
@@ -76,40 +73,21 @@ func (c *Client) GetAllReleases(ctx context.Context, owner, repo string) ([]Rele
 
 // getReleasesPage 获取指定页的 releases
 func (c *Client) getReleasesPage(ctx context.Context, owner, repo string, page, perPage int) ([]Release, bool, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases?page=%d&per_page=%d",
-		owner, repo, page, perPage)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, false, err
-	}
+	url := fmt.Sprintf("%s/repos/%s/%s/releases?page=%d&per_page=%d",
+		c.baseURL, owner, repo, page, perPage)
 
-	// 设置认证头
-	if c.token != "" {
-		req.Header.Set("Authorization", "token "+c.token)
-	}
-
-	// 设置 User-Agent
-	req.Header.Set("User-Agent", "Go-GitHub-Releases-Fetcher/1.0")
-
-	resp, err := c.httpClient.Do(req)
+	body, header, err := http.Default().GetGitHub(ctx, url, c.token)
 	if err != nil {
 		return nil, false, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, false, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
-	}
 
 	var releases []Release
-	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+	if err := json.Unmarshal(body, &releases); err != nil {
 		return nil, false, err
 	}
 
 	// 检查是否有下一页
-	hasNext := c.hasNextPage(resp.Header.Get("Link"))
+	hasNext := c.hasNextPage(header.Get("Link"))
 
 	return releases, hasNext, nil
 }
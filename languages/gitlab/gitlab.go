@@ -0,0 +1,140 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/http"
+	"github.com/toodofun/gvm/internal/util/asset"
+	"github.com/toodofun/gvm/languages/release"
+)
+
+const defaultURL = "https://gitlab.com"
+
+// Release GitLab Releases API 返回的发布版本
+type Release struct {
+	Name            string `json:"name"`
+	TagName         string `json:"tag_name"`
+	UpcomingRelease bool   `json:"upcoming_release"`
+	Assets          struct {
+		Links []Link `json:"links"`
+	} `json:"assets"`
+}
+
+// Link 发布版本中的安装包链接
+type Link struct {
+	Name           string `json:"name"`
+	URL            string `json:"url"`
+	DirectAssetURL string `json:"direct_asset_url"`
+}
+
+// Gitlab 使用 GitLab Releases 作为数据源的插件
+type Gitlab struct {
+	*release.Addon
+	baseURL string
+	project string
+}
+
+// instance GitLab 实例，地址依次读取 GVM_GITLAB_URL、配置 gitlab.url，默认为 gitlab.com；
+// token 依次读取 GITLAB_TOKEN 和配置 gitlab.token
+var instance = &release.Instance{
+	DefaultURL: defaultURL,
+	URLEnv:     "GVM_GITLAB_URL",
+	TokenEnv:   "GITLAB_TOKEN",
+	Config: func(config *core.Config) (string, string) {
+		if config.GitLab == nil {
+			return "", ""
+		}
+		return config.GitLab.URL, config.GitLab.Token
+	},
+	Header: "PRIVATE-TOKEN",
+}
+
+// Headers 为 API 请求和安装包下载提供 private token
+func (g *Gitlab) Headers(url string) map[string]string {
+	return instance.Headers(g.baseURL, url)
+}
+
+// ListReleases 分页获取项目的所有发布版本，tag 作为版本名称
+func (g *Gitlab) ListReleases(ctx context.Context) ([]*release.Release, error) {
+	res := make([]*release.Release, 0)
+	for page := "1"; page != ""; {
+		api := fmt.Sprintf("%s/api/v4/projects/%s/releases?per_page=100&page=%s",
+			g.baseURL, url.PathEscape(g.project), page)
+		body, respHeader, err := http.Default().GetWithHeader(ctx, api, g.Headers(api))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get releases of %s: %w", g.project, err)
+		}
+		releases := make([]Release, 0)
+		if err := json.Unmarshal(body, &releases); err != nil {
+			return nil, fmt.Errorf("failed to parse releases of %s: %w", g.project, err)
+		}
+		for _, r := range releases {
+			item := &release.Release{Name: r.TagName, Prerelease: r.UpcomingRelease}
+			for _, link := range r.Assets.Links {
+				u := link.DirectAssetURL
+				if u == "" {
+					u = link.URL
+				}
+				item.Assets = append(item.Assets, core.Asset{Name: link.Name, URL: u})
+			}
+			res = append(res, item)
+		}
+		page = respHeader.Get("X-Next-Page")
+	}
+	return res, nil
+}
+
+// NewGitlab 创建 GitLab 插件，数据源格式为 [https://<host>/]<group>/<project>[?<asset rules>]，
+// 不写实例地址时使用 instance 的地址
+func NewGitlab(name, dsn string) (*Gitlab, error) {
+	repository, query, _ := strings.Cut(dsn, "?")
+	baseURL := instance.URL()
+	if strings.HasPrefix(repository, "http://") || strings.HasPrefix(repository, "https://") {
+		u, err := url.Parse(repository)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data source name: %s: %w", dsn, err)
+		}
+		baseURL = u.Scheme + "://" + u.Host
+		repository = u.Path
+	}
+	repository = strings.Trim(repository, "/")
+	if !strings.Contains(repository, "/") || strings.Contains(repository, "//") {
+		return nil, fmt.Errorf("invalid data source name: %s, expected format: [https://<host>/]<group>/<project>[?<asset rules>]", dsn)
+	}
+	rules, err := asset.ParseRules(query)
+	if err != nil {
+		return nil, err
+	}
+	g := &Gitlab{
+		baseURL: baseURL,
+		project: repository,
+	}
+	g.Addon = release.NewAddon(name, path.Base(repository), g, rules)
+	return g, nil
+}
+
+func init() {
+	core.RegisterAddonProvider("gitlab", func(name, dsn string) (core.Language, error) {
+		return NewGitlab(name, dsn)
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/testutil"
+)
+
+// newFakeGitlab 模拟 GitLab Releases API，每页返回一个发布版本
+func newFakeGitlab(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	asset := fmt.Sprintf("tool-%s-%s", runtime.GOOS, runtime.GOARCH)
+	pages := map[string]Release{
+		"1": {Name: "Tool 1.1.0", TagName: "v1.1.0"},
+		"2": {Name: "Tool 1.0.0", TagName: "v1.0.0"},
+	}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fsub%2Ftool/releases":
+			assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+			page := r.URL.Query().Get("page")
+			release := pages[page]
+			release.Assets.Links = []Link{
+				{Name: asset + ".sha256", URL: server.URL + "/download/checksum"},
+				{Name: asset, URL: server.URL + "/-/releases/" + release.TagName, DirectAssetURL: server.URL + "/download/tool"},
+			}
+			if page == "1" {
+				w.Header().Set("X-Next-Page", "2")
+			}
+			_ = json.NewEncoder(w).Encode([]Release{release})
+		case "/download/tool":
+			// 私有项目的安装包同样需要 token，GitLab 对无权访问的资源返回 404
+			if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = io.WriteString(w, "#!/bin/sh\necho tool\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return server
+}
+
+func TestGitlab(t *testing.T) {
+	root := testutil.SetRootDir(t)
+
+	server := newFakeGitlab(t)
+	defer server.Close()
+	t.Setenv("GVM_GITLAB_URL", server.URL)
+	t.Setenv("GITLAB_TOKEN", "secret")
+	ctx := context.Background()
+
+	g, err := NewGitlab("tool", "group/sub/tool?bin=tool")
+	require.NoError(t, err)
+
+	versions, err := g.ListRemoteVersions(ctx)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "v1.1.0", versions[0].Origin)
+	assert.Equal(t, "1.0.0", versions[1].Version.String())
+
+	selected, rejected, err := g.ResolveAsset(ctx, versions[0])
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/download/tool", selected.URL)
+	require.Len(t, rejected, 1)
+	assert.Contains(t, rejected[0].Reason, "excluded")
+
+	require.NoError(t, g.Install(ctx, versions[0]))
+	info, err := os.Stat(filepath.Join(root, "tool", "1.1.0", "bin", "tool"))
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	}
+	assert.Equal(t, "tool", g.Executable())
+}
+
+func TestNewGitlab(t *testing.T) {
+	t.Setenv("GVM_GITLAB_URL", "")
+	g, err := NewGitlab("tool", "https://gitlab.example.com/group/tool?formats=raw")
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitlab.example.com", g.baseURL)
+	assert.Equal(t, "group/tool", g.project)
+
+	_, err = NewGitlab("tool", "tool")
+	assert.ErrorContains(t, err, "invalid data source name")
+	_, err = NewGitlab("tool", "group/tool?unknown=1")
+	assert.ErrorContains(t, err, "unknown asset rule")
+}
@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/toodofun/gvm/i18n"
 
@@ -30,6 +32,7 @@ import (
 	"github.com/toodofun/gvm/internal/log"
 	"github.com/toodofun/gvm/internal/util/compress"
 	"github.com/toodofun/gvm/internal/util/env"
+	"github.com/toodofun/gvm/internal/util/eol"
 	"github.com/toodofun/gvm/internal/util/path"
 	"github.com/toodofun/gvm/internal/util/slice"
 	"github.com/toodofun/gvm/languages"
@@ -106,9 +109,16 @@ func (g *Golang) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVe
 }
 
 func (g *Golang) SetDefaultVersion(ctx context.Context, version string) error {
-	gopath := filepath.Join(path.GetLangRoot(g.Name()), "gopath")
-	_ = os.MkdirAll(gopath, os.ModePerm)
-	envs := []env.KV{
+	_ = os.MkdirAll(g.gopath(), os.ModePerm)
+	return languages.NewLanguage(g).SetDefaultVersion(ctx, version, g.Envs())
+}
+
+func (g *Golang) gopath() string {
+	return filepath.Join(path.GetLangRoot(g.Name()), "gopath")
+}
+
+func (g *Golang) Envs() []env.KV {
+	return []env.KV{
 		{
 			Key:    "PATH",
 			Value:  filepath.Join(path.GetLangRoot(g.Name()), path.Current, "go", "bin"),
@@ -120,15 +130,14 @@ func (g *Golang) SetDefaultVersion(ctx context.Context, version string) error {
 		},
 		{
 			Key:   "GOPATH",
-			Value: gopath,
+			Value: g.gopath(),
 		},
 		{
 			Key:    "PATH",
-			Value:  filepath.Join(gopath, "bin"),
+			Value:  filepath.Join(g.gopath(), "bin"),
 			Append: true,
 		},
 	}
-	return languages.NewLanguage(g).SetDefaultVersion(ctx, version, envs)
 }
 
 func (g *Golang) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
@@ -136,7 +145,15 @@ func (g *Golang) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
 }
 
 func (g *Golang) Uninstall(ctx context.Context, version string) error {
-	return languages.NewLanguage(g).Uninstall(version)
+	return languages.NewLanguage(g).Uninstall(ctx, version, g.Envs())
+}
+
+func (g *Golang) UnsetDefaultVersion(ctx context.Context) error {
+	return languages.NewLanguage(g).UnsetDefaultVersion(ctx, g.Envs())
+}
+
+func (g *Golang) Executable() string {
+	return "go"
 }
 
 func (g *Golang) Install(ctx context.Context, version *core.RemoteVersion) error {
@@ -182,29 +199,59 @@ func (g *Golang) Install(ctx context.Context, version *core.RemoteVersion) error
 
 	logger.Debugf("Downloading: %s, size: %s", url, head.Get("Content-Length"))
 	file, err := http.Default().
-		Download(ctx, url, filepath.Join(path.GetLangRoot(lang), version.Version.String()), fmt.Sprintf("%s.%s-%s.tar.gz", version.Origin, runtime.GOOS, "amd64"))
+		Download(ctx, url, filepath.Join(path.GetLangRoot(lang), version.Version.String()), filepath.Base(url))
 	logger.Infof("")
 	if err != nil {
 		return fmt.Errorf("failed to download version %s: %w", version.Version.String(), err)
 	}
-	logger.Infof("📁 %s", i18n.GetTranslate("languages.extracting", nil))
-	if strings.HasSuffix(url, ".tar.gz") {
-		if err := compress.UnTarGz(ctx, file, filepath.Join(core.GetRootDir(), "go", version.Version.String())); err != nil {
-			logger.Warnf("Failed to untar version %s: %s", version.Version.String(), err)
-			return fmt.Errorf("failed to extract version %s: %w", version.Version.String(), err)
-		}
-	} else if strings.HasSuffix(url, ".zip") {
-		if err := compress.UnZip(ctx, file, filepath.Join(core.GetRootDir(), "go", version.Version.String())); err != nil {
-			logger.Warnf("Failed to untar version %s: %s", version.Version.String(), err)
-			return fmt.Errorf("failed to extract version %s: %w", version.Version.String(), err)
-		}
+	if err := extract(ctx, file, version); err != nil {
+		return err
 	}
 
 	if err = os.RemoveAll(file); err != nil {
 		logger.Warnf("Failed to clean %s: %v", file, err)
 	}
 
-	logger.Infof(
+	installComplete(ctx, version)
+	return nil
+}
+
+func (g *Golang) InstallFromFile(ctx context.Context, version *core.RemoteVersion, file string) error {
+	if err, exist := languages.HasInstall(ctx, g, *version.Version); err != nil || exist {
+		return err
+	}
+	if err := extract(ctx, file, version); err != nil {
+		languages.NewLanguage(g).CleanVersion(ctx, version.Version.String())
+		return err
+	}
+	installComplete(ctx, version)
+	return nil
+}
+
+func (g *Golang) InstallFromDir(ctx context.Context, version *core.RemoteVersion, dir string) error {
+	if err, exist := languages.HasInstall(ctx, g, *version.Version); err != nil || exist {
+		return err
+	}
+	if err := languages.NewLanguage(g).InstallFromDir(ctx, version.Version.String(), dir, "go"); err != nil {
+		return err
+	}
+	installComplete(ctx, version)
+	return nil
+}
+
+// extract 解压官方安装包，安装包内的 go 目录会被放在版本目录下
+func extract(ctx context.Context, file string, version *core.RemoteVersion) error {
+	logger := log.GetLogger(ctx)
+	logger.Infof("📁 %s", i18n.GetTranslate("languages.extracting", nil))
+	if err := compress.Extract(ctx, file, filepath.Join(path.GetLangRoot(lang), version.Version.String())); err != nil {
+		logger.Warnf("Failed to extract version %s: %s", version.Version.String(), err)
+		return fmt.Errorf("failed to extract version %s: %w", version.Version.String(), err)
+	}
+	return nil
+}
+
+func installComplete(ctx context.Context, version *core.RemoteVersion) {
+	log.GetLogger(ctx).Infof(
 		"✅ %s",
 		i18n.GetTranslate("languages.installComplete", map[string]any{
 			"lang":     lang,
@@ -212,7 +259,61 @@ func (g *Golang) Install(ctx context.Context, version *core.RemoteVersion) error
 			"location": filepath.Join(path.GetLangRoot(lang), version.Version.String(), "go", "bin"),
 		}),
 	)
-	return nil
+}
+
+var versionPattern = regexp.MustCompile(`go(\d+(\.\d+)*([a-z]+\d+)?)`)
+
+// Link 登记外部安装的 Go，target 为 GOROOT
+func (g *Golang) Link(ctx context.Context, name, target string, version *goversion.Version) (*core.InstalledVersion, error) {
+	if version == nil {
+		var err error
+		version, err = languages.DetectVersion(ctx, filepath.Join(target, "bin", "go"), []string{"version"}, versionPattern)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return languages.NewLanguage(g).Link(ctx, name, target, "go", version)
+}
+
+// FetchLifecycles 按 Go 的发布策略推算生命周期：每个版本线在之后第二个版本线发布时停止维护，
+// 因此最新的两个版本线仍在维护，更早的版本线沿用已知日期，未知时记为当前时间
+func (g *Golang) FetchLifecycles(ctx context.Context) ([]*core.Lifecycle, error) {
+	remoteVersions, err := g.ListRemoteVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return goLifecycles(remoteVersions, eol.Load(lang), time.Now()), nil
+}
+
+func goLifecycles(remoteVersions []*core.RemoteVersion, known []*core.Lifecycle, now time.Time) []*core.Lifecycle {
+	seen := make(map[string]bool)
+	res := make([]*core.Lifecycle, 0)
+	for _, rv := range remoteVersions {
+		if rv.Version.Prerelease() != "" {
+			continue
+		}
+		s := rv.Version.Segments()
+		cycle := fmt.Sprintf("%d.%d", s[0], s[1])
+		if seen[cycle] {
+			continue
+		}
+		seen[cycle] = true
+		res = append(res, &core.Lifecycle{Cycle: cycle})
+	}
+	eol.Sort(res)
+
+	dates := make(map[string]time.Time)
+	for _, c := range known {
+		dates[c.Cycle] = c.EOL
+	}
+	for i := 0; i < len(res)-2; i++ {
+		if date, ok := dates[res[i].Cycle]; ok && !date.IsZero() {
+			res[i].EOL = date
+		} else {
+			res[i].EOL = now
+		}
+	}
+	return res
 }
 
 func init() {
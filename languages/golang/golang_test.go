@@ -15,10 +15,22 @@
 package golang
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/testutil"
+
+	goversion "github.com/hashicorp/go-version"
 )
 
 func TestGolang_ListRemoteVersions(t *testing.T) {
@@ -40,3 +52,43 @@ func TestGolang_GetDefaultVersion(t *testing.T) {
 	defaultVersion := golang.GetDefaultVersion(context.Background())
 	assert.NotNil(t, defaultVersion)
 }
+
+func TestGoLifecycles(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	var remoteVersions []*core.RemoteVersion
+	for _, v := range []string{"1.25rc1", "1.24.3", "1.24.0", "1.23.9", "1.22.1", "1.21.0"} {
+		remoteVersions = append(remoteVersions, &core.RemoteVersion{Version: goversion.Must(goversion.NewVersion(v))})
+	}
+	known := []*core.Lifecycle{{Cycle: "1.22", EOL: time.Date(2025, 2, 11, 0, 0, 0, 0, time.UTC)}}
+
+	cycles := goLifecycles(remoteVersions, known, now)
+	assert.Len(t, cycles, 4)
+	assert.Equal(t, "1.21", cycles[0].Cycle)
+	assert.Equal(t, now, cycles[0].EOL)
+	assert.Equal(t, known[0].EOL, cycles[1].EOL)
+	assert.True(t, cycles[2].EOL.IsZero())
+	assert.True(t, cycles[3].EOL.IsZero())
+}
+
+func TestGolang_InstallFromFileCleansUp(t *testing.T) {
+	root := testutil.SetRootDir(t)
+
+	// 截断的安装包：第一个文件可以解压，之后读取失败
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range []string{"go/VERSION", "go/bin/go"} {
+		data := bytes.Repeat([]byte("x"), 64*1024)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data))}))
+		_, err := tw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	file := filepath.Join(t.TempDir(), "go1.22.0.linux-amd64.tar.gz")
+	require.NoError(t, os.WriteFile(file, buf.Bytes()[:buf.Len()/2], 0644))
+
+	version := &core.RemoteVersion{Version: goversion.Must(goversion.NewVersion("1.22.0")), Origin: "go1.22.0"}
+	assert.Error(t, (&Golang{}).InstallFromFile(context.Background(), version, file))
+	assert.NoDirExists(t, filepath.Join(root, "go", "1.22.0"), "a partly extracted version is removed")
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 
@@ -38,7 +39,7 @@ import (
 
 const (
 	lang            = "gvm"
-	apiBaseUrl      = "https://api.github.com/repos/toodofun/gvm/releases"
+	apiBaseUrl      = http.PublicGitHubAPIURL + "/repos/toodofun/gvm/releases"
 	downloadBaseUrl = "https://github.com/toodofun/gvm/releases/download/%s/gvm-%s-%s-%s.%s"
 )
 
@@ -57,7 +58,7 @@ func (g *GVM) Name() string {
 func (g *GVM) ListRemoteVersions(ctx context.Context) ([]*core.RemoteVersion, error) {
 	logger := log.GetLogger(ctx)
 	res := make([]*core.RemoteVersion, 0)
-	body, err := http.Default().Get(ctx, apiBaseUrl)
+	body, _, err := http.Default().GetGitHub(ctx, apiBaseUrl, "")
 	if err != nil {
 		logger.Errorf("Get remote versions error: %v", err)
 		return nil, err
@@ -94,14 +95,17 @@ func (g *GVM) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVersi
 }
 
 func (g *GVM) SetDefaultVersion(ctx context.Context, version string) error {
-	envs := []env.KV{
+	return languages.NewLanguage(g).SetDefaultVersion(ctx, version, g.Envs())
+}
+
+func (g *GVM) Envs() []env.KV {
+	return []env.KV{
 		{
 			Key:    "PATH",
 			Value:  filepath.Join(path.GetLangRoot(g.Name()), path.Current),
 			Append: true,
 		},
 	}
-	return languages.NewLanguage(g).SetDefaultVersion(ctx, version, envs)
 }
 
 func (g *GVM) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
@@ -148,23 +152,51 @@ func (g *GVM) Install(ctx context.Context, remoteVersion *core.RemoteVersion) er
 		return fmt.Errorf("failed to download version %s: %w", remoteVersion.Version.String(), err)
 	}
 
-	if strings.HasSuffix(url, ".tar.gz") {
-		if err := compress.UnTarGz(ctx, file, filepath.Join(path.GetLangRoot(lang), remoteVersion.Version.String())); err != nil {
-			logger.Warnf("Failed to untar version %s: %s", remoteVersion.Version.String(), err)
-			return fmt.Errorf("failed to extract version %s: %w", remoteVersion.Version.String(), err)
-		}
-	} else if strings.HasSuffix(url, ".zip") {
-		if err := compress.UnZip(ctx, file, filepath.Join(path.GetLangRoot(lang), remoteVersion.Version.String())); err != nil {
-			logger.Warnf("Failed to untar version %s: %s", remoteVersion.Version.String(), err)
-			return fmt.Errorf("failed to extract version %s: %w", remoteVersion.Version.String(), err)
-		}
+	if err := extract(ctx, file, remoteVersion); err != nil {
+		return err
 	}
 
 	if err = os.RemoveAll(file); err != nil {
 		logger.Warnf("Failed to clean %s: %v", file, err)
 	}
 
-	logger.Infof(
+	installComplete(ctx, remoteVersion)
+	return nil
+}
+
+func (g *GVM) InstallFromFile(ctx context.Context, remoteVersion *core.RemoteVersion, file string) error {
+	if err, exist := languages.HasInstall(ctx, g, *remoteVersion.Version); err != nil || exist {
+		return err
+	}
+	if err := extract(ctx, file, remoteVersion); err != nil {
+		languages.NewLanguage(g).CleanVersion(ctx, remoteVersion.Version.String())
+		return err
+	}
+	installComplete(ctx, remoteVersion)
+	return nil
+}
+
+func (g *GVM) InstallFromDir(ctx context.Context, remoteVersion *core.RemoteVersion, dir string) error {
+	if err, exist := languages.HasInstall(ctx, g, *remoteVersion.Version); err != nil || exist {
+		return err
+	}
+	if err := languages.NewLanguage(g).InstallFromDir(ctx, remoteVersion.Version.String(), dir, ""); err != nil {
+		return err
+	}
+	installComplete(ctx, remoteVersion)
+	return nil
+}
+
+func extract(ctx context.Context, file string, remoteVersion *core.RemoteVersion) error {
+	if err := compress.Extract(ctx, file, filepath.Join(path.GetLangRoot(lang), remoteVersion.Version.String())); err != nil {
+		log.GetLogger(ctx).Warnf("Failed to extract version %s: %s", remoteVersion.Version.String(), err)
+		return fmt.Errorf("failed to extract version %s: %w", remoteVersion.Version.String(), err)
+	}
+	return nil
+}
+
+func installComplete(ctx context.Context, remoteVersion *core.RemoteVersion) {
+	log.GetLogger(ctx).Infof(
 		"✅ %s",
 		i18n.GetTranslate("languages.installComplete", map[string]any{
 			"lang":     lang,
@@ -172,11 +204,32 @@ func (g *GVM) Install(ctx context.Context, remoteVersion *core.RemoteVersion) er
 			"location": filepath.Join(path.GetLangRoot(lang), remoteVersion.Version.String()),
 		}),
 	)
-	return nil
 }
 
 func (g *GVM) Uninstall(ctx context.Context, version string) error {
-	return languages.NewLanguage(g).Uninstall(version)
+	return languages.NewLanguage(g).Uninstall(ctx, version, g.Envs())
+}
+
+func (g *GVM) UnsetDefaultVersion(ctx context.Context) error {
+	return languages.NewLanguage(g).UnsetDefaultVersion(ctx, g.Envs())
+}
+
+func (g *GVM) Executable() string {
+	return "gvm"
+}
+
+var versionPattern = regexp.MustCompile(`GitVersion:v?(\d+\.\d+\.\d+[^ ,}]*)`)
+
+// Link 登记外部安装的 gvm，target 为包含 gvm 可执行文件的目录
+func (g *GVM) Link(ctx context.Context, name, target string, version *goversion.Version) (*core.InstalledVersion, error) {
+	if version == nil {
+		var err error
+		version, err = languages.DetectVersion(ctx, filepath.Join(target, lang), []string{"version"}, versionPattern)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return languages.NewLanguage(g).Link(ctx, name, target, "", version)
 }
 
 func init() {
@@ -16,9 +16,16 @@ package languages
 
 import (
 	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/toodofun/gvm/internal/core"
 	"github.com/toodofun/gvm/internal/log"
+	"github.com/toodofun/gvm/internal/util/manifest"
+	"github.com/toodofun/gvm/internal/util/path"
 
 	goversion "github.com/hashicorp/go-version"
 )
@@ -32,10 +39,41 @@ func HasInstall(ctx context.Context, language core.Language, version goversion.V
 		return err, false
 	}
 	for _, ver := range installed {
-		if ver.Version.Equal(&version) {
+		// 使用字符串比较，避免 gvm link 登记的 <version>+<name> 与同版本号的安装混淆
+		if ver.Version.String() == version.String() {
 			logger.Infof("Version %s already installed", version.String())
 			return nil, true
 		}
 	}
 	return nil, false
 }
+
+// RecordManifest 记录安装目录的文件清单，供 gvm verify 校验；已存在的清单不会被覆盖
+func RecordManifest(ctx context.Context, language core.Language, version string) error {
+	logger := log.GetLogger(ctx)
+	if _, err := manifest.Load(language.Name(), version); err == nil {
+		logger.Debugf("Manifest of %s %s already recorded", language.Name(), version)
+		return nil
+	}
+	m, err := manifest.Generate(language.Name(), version, filepath.Join(path.GetLangRoot(language.Name()), version))
+	if err != nil {
+		return fmt.Errorf("failed to record manifest of %s %s: %w", language.Name(), version, err)
+	}
+	logger.Debugf("Recorded %d files for %s %s", len(m.Files), language.Name(), version)
+	return manifest.Save(m)
+}
+
+// DetectVersion 执行工具链二进制并从输出中提取版本号，pattern 的第一个分组为版本号
+func DetectVersion(ctx context.Context, bin string, args []string, pattern *regexp.Regexp) (*goversion.Version, error) {
+	logger := log.GetLogger(ctx)
+	out, err := exec.CommandContext(ctx, bin, args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", bin, err)
+	}
+	logger.Debugf("%s %s: %s", bin, strings.Join(args, " "), out)
+	matches := pattern.FindStringSubmatch(string(out))
+	if len(matches) < 2 {
+		return nil, fmt.Errorf("can not detect version from output of %s", bin)
+	}
+	return goversion.NewVersion(matches[1])
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 
 	"github.com/toodofun/gvm/i18n"
@@ -29,6 +30,8 @@ import (
 	"github.com/toodofun/gvm/internal/util/env"
 	"github.com/toodofun/gvm/internal/util/path"
 	"github.com/toodofun/gvm/languages"
+
+	goversion "github.com/hashicorp/go-version"
 )
 
 const (
@@ -69,14 +72,21 @@ func (j *Java) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVers
 }
 
 func (j *Java) SetDefaultVersion(ctx context.Context, version string) error {
-	envs := []env.KV{
+	return languages.NewLanguage(j).SetDefaultVersion(ctx, version, j.Envs())
+}
+
+func (j *Java) Envs() []env.KV {
+	return []env.KV{
 		{
 			Key:    "PATH",
 			Value:  filepath.Join(path.GetLangRoot(lang), path.Current, "bin"),
 			Append: true,
 		},
+		{
+			Key:   "JAVA_HOME",
+			Value: filepath.Join(path.GetLangRoot(lang), path.Current),
+		},
 	}
-	return languages.NewLanguage(j).SetDefaultVersion(ctx, version, envs)
 }
 
 func (j *Java) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
@@ -84,7 +94,15 @@ func (j *Java) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
 }
 
 func (j *Java) Uninstall(ctx context.Context, version string) error {
-	return languages.NewLanguage(j).Uninstall(version)
+	return languages.NewLanguage(j).Uninstall(ctx, version, j.Envs())
+}
+
+func (j *Java) UnsetDefaultVersion(ctx context.Context) error {
+	return languages.NewLanguage(j).UnsetDefaultVersion(ctx, j.Envs())
+}
+
+func (j *Java) Executable() string {
+	return "java"
 }
 
 func (j *Java) Install(ctx context.Context, version *core.RemoteVersion) error {
@@ -104,9 +122,7 @@ func (j *Java) Install(ctx context.Context, version *core.RemoteVersion) error {
 	}
 
 	installDir := filepath.Join(path.GetLangRoot(lang), version.Version.String())
-	logger.Infof("📁 解压 Java 安装包...")
-
-	if err := compress.UnTarGz(ctx, file, installDir); err != nil {
+	if err := extract(ctx, file, installDir); err != nil {
 		return fmt.Errorf("failed to unTarGz: %s(%s): %w", version.Version.String(), version.Comment, err)
 	}
 
@@ -114,6 +130,49 @@ func (j *Java) Install(ctx context.Context, version *core.RemoteVersion) error {
 		logger.Warnf("failed to clean %s: %v", file, err)
 	}
 
+	if err := flatten(ctx, installDir); err != nil {
+		return err
+	}
+	installComplete(ctx, version, installDir)
+	return nil
+}
+
+func (j *Java) InstallFromFile(ctx context.Context, version *core.RemoteVersion, file string) error {
+	if err, exist := languages.HasInstall(ctx, j, *version.Version); err != nil || exist {
+		return err
+	}
+	installDir := filepath.Join(path.GetLangRoot(lang), version.Version.String())
+	if err := extract(ctx, file, installDir); err != nil {
+		languages.NewLanguage(j).CleanVersion(ctx, version.Version.String())
+		return fmt.Errorf("failed to extract %s: %w", file, err)
+	}
+	if err := flatten(ctx, installDir); err != nil {
+		languages.NewLanguage(j).CleanVersion(ctx, version.Version.String())
+		return err
+	}
+	installComplete(ctx, version, installDir)
+	return nil
+}
+
+func (j *Java) InstallFromDir(ctx context.Context, version *core.RemoteVersion, dir string) error {
+	if err, exist := languages.HasInstall(ctx, j, *version.Version); err != nil || exist {
+		return err
+	}
+	if err := languages.NewLanguage(j).InstallFromDir(ctx, version.Version.String(), dir, ""); err != nil {
+		return err
+	}
+	installComplete(ctx, version, filepath.Join(path.GetLangRoot(lang), version.Version.String()))
+	return nil
+}
+
+func extract(ctx context.Context, file, installDir string) error {
+	log.GetLogger(ctx).Infof("📁 解压 Java 安装包...")
+	return compress.Extract(ctx, file, installDir)
+}
+
+// flatten 将安装包内的顶层目录内容移动到版本目录下
+func flatten(ctx context.Context, installDir string) error {
+	logger := log.GetLogger(ctx)
 	logger.Infof("🔧 整理 Java 安装文件...")
 	dirs, err := filepath.Glob(filepath.Join(installDir, "/*"))
 	if err != nil {
@@ -142,8 +201,11 @@ func (j *Java) Install(ctx context.Context, version *core.RemoteVersion) error {
 			}
 		}
 	}
+	return nil
+}
 
-	logger.Infof(
+func installComplete(ctx context.Context, version *core.RemoteVersion, installDir string) {
+	log.GetLogger(ctx).Infof(
 		"✅ %s",
 		i18n.GetTranslate("languages.installComplete", map[string]any{
 			"lang":     lang,
@@ -151,8 +213,20 @@ func (j *Java) Install(ctx context.Context, version *core.RemoteVersion) error {
 			"location": installDir,
 		}),
 	)
+}
 
-	return nil
+var versionPattern = regexp.MustCompile(`version "(\d+(\.\d+)*)`)
+
+// Link 登记外部安装的 JDK，target 为 JAVA_HOME
+func (j *Java) Link(ctx context.Context, name, target string, version *goversion.Version) (*core.InstalledVersion, error) {
+	if version == nil {
+		var err error
+		version, err = languages.DetectVersion(ctx, filepath.Join(target, "bin", "java"), []string{"-version"}, versionPattern)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return languages.NewLanguage(j).Link(ctx, name, target, "", version)
 }
 
 type Version struct {
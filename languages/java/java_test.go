@@ -16,7 +16,10 @@ package java
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
+
+	"github.com/toodofun/gvm/internal/util/path"
 )
 
 func TestJava_Name(t *testing.T) {
@@ -61,3 +64,19 @@ func TestJava_Uninstall(t *testing.T) {
 		t.Errorf("Uninstall should not return error for non-existent version, got: %v", err)
 	}
 }
+
+// gvm use java 同时导出指向 current 的 JAVA_HOME，gvm unuse java 会一并清理
+func TestJava_Envs(t *testing.T) {
+	j := &Java{}
+	current := filepath.Join(path.GetLangRoot("java"), path.Current)
+	envs := j.Envs()
+	if len(envs) != 2 {
+		t.Fatalf("expected PATH and JAVA_HOME, got %+v", envs)
+	}
+	if envs[0].Key != "PATH" || envs[0].Value != filepath.Join(current, "bin") || !envs[0].Append {
+		t.Errorf("unexpected PATH entry: %+v", envs[0])
+	}
+	if envs[1].Key != "JAVA_HOME" || envs[1].Value != current || envs[1].Append {
+		t.Errorf("unexpected JAVA_HOME entry: %+v", envs[1])
+	}
+}
@@ -19,15 +19,22 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/toodofun/gvm/internal/core"
 	"github.com/toodofun/gvm/internal/log"
 	"github.com/toodofun/gvm/internal/util/env"
+	"github.com/toodofun/gvm/internal/util/file"
+	"github.com/toodofun/gvm/internal/util/manifest"
 	"github.com/toodofun/gvm/internal/util/path"
+	"github.com/toodofun/gvm/internal/util/usage"
 
 	goversion "github.com/hashicorp/go-version"
 )
 
+var linkNamePattern = regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`)
+
 // Language 默认方法
 type Language struct {
 	lang core.Language
@@ -120,7 +127,130 @@ func (l *Language) ListInstalledVersions(ctx context.Context, binPath string) ([
 	return res, nil
 }
 
-func (l *Language) Uninstall(version string) error {
+// Uninstall 删除指定版本，envs 为该语言设置默认版本时写入的环境变量。
+// 删除的是当前版本或最后一个版本时，会同时清理 current 软链接和环境变量
+func (l *Language) Uninstall(ctx context.Context, version string, envs []env.KV) error {
 	source := filepath.Join(path.GetLangRoot(l.lang.Name()), version)
-	return os.RemoveAll(source)
+	info, err := os.Lstat(source)
+	if err != nil {
+		return l.removeRecords(version)
+	}
+	current := l.GetDefaultVersion()
+	isCurrent := len(current.Location) > 0 && filepath.Base(current.Location) == version
+
+	// 通过 gvm link 登记的版本只删除软链接本身，RemoveAll 不会跟随目录内的软链接
+	if info.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(source); err != nil {
+			return err
+		}
+	} else if err := os.RemoveAll(source); err != nil {
+		return err
+	}
+	if err := l.removeRecords(version); err != nil {
+		return err
+	}
+
+	remaining, err := l.lang.ListInstalledVersions(ctx)
+	if err != nil {
+		return err
+	}
+	if isCurrent || len(remaining) == 0 {
+		return l.UnsetDefaultVersion(ctx, envs)
+	}
+	return nil
+}
+
+// removeRecords 删除版本对应的清单和使用记录
+func (l *Language) removeRecords(version string) error {
+	if err := manifest.Remove(l.lang.Name(), version); err != nil {
+		return err
+	}
+	return usage.Remove(l.lang.Name(), version)
+}
+
+// UnsetDefaultVersion 删除 current 软链接，并从配置中移除 envs 对应的环境变量
+func (l *Language) UnsetDefaultVersion(ctx context.Context, envs []env.KV) error {
+	logger := log.GetLogger(ctx)
+	target := filepath.Join(path.GetLangRoot(l.lang.Name()), path.Current)
+	if _, err := os.Lstat(target); err == nil {
+		if err := os.Remove(target); err != nil {
+			return fmt.Errorf("failed to remove symlink %s: %w", target, err)
+		}
+	}
+
+	em := env.NewEnvManager()
+	for _, kv := range envs {
+		logger.Debugf("Remove env %s=%s", kv.Key, kv.Value)
+		if kv.Append {
+			if err := em.RemoveEnv(kv.Key, kv.Value); err != nil {
+				return fmt.Errorf("remove from env (%s, %s) error: %w", kv.Key, kv.Value, err)
+			}
+		} else {
+			if err := em.DeleteEnv(kv.Key); err != nil {
+				return fmt.Errorf("delete env %s error: %w", kv.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// InstallFromDir 将已解压的目录复制到版本目录下的 sub 子目录
+func (l *Language) InstallFromDir(ctx context.Context, version, dir, sub string) error {
+	logger := log.GetLogger(ctx)
+	dest := filepath.Join(path.GetLangRoot(l.lang.Name()), version, sub)
+	if path.IsPathExist(dest) {
+		return fmt.Errorf("%s already exists", dest)
+	}
+	logger.Infof("Copying %s to %s", dir, dest)
+	if err := file.CopyDir(dir, dest); err != nil {
+		l.CleanVersion(ctx, version)
+		return fmt.Errorf("failed to copy %s: %w", dir, err)
+	}
+	return nil
+}
+
+// CleanVersion 删除安装失败的版本目录，避免残缺的目录被当作已安装的版本
+func (l *Language) CleanVersion(ctx context.Context, version string) {
+	dir := filepath.Join(path.GetLangRoot(l.lang.Name()), version)
+	if err := os.RemoveAll(dir); err != nil {
+		log.GetLogger(ctx).Warnf("Failed to clean %s: %v", dir, err)
+	}
+}
+
+// Link 将外部安装的工具链以软链接方式登记到版本目录，sub 为工具链在版本目录中的位置。
+// 版本目录名为 <version>+<name>，以便与 gvm 自己安装的版本区分
+func (l *Language) Link(ctx context.Context, name, target, sub string, version *goversion.Version) (*core.InstalledVersion, error) {
+	logger := log.GetLogger(ctx)
+	if !path.IsDir(target) {
+		return nil, fmt.Errorf("%s is not a directory", target)
+	}
+
+	dirName := version.String()
+	if len(name) > 0 {
+		if !linkNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid link name %s, only [0-9A-Za-z-.] are allowed", name)
+		}
+		dirName = fmt.Sprintf("%s+%s", strings.SplitN(version.String(), "+", 2)[0], name)
+	}
+
+	location := filepath.Join(path.GetLangRoot(l.lang.Name()), dirName)
+	if _, err := os.Lstat(location); err == nil {
+		return nil, fmt.Errorf("%s %s already exists", l.lang.Name(), dirName)
+	}
+
+	link := filepath.Join(location, sub)
+	if err := os.MkdirAll(filepath.Dir(link), 0755); err != nil {
+		return nil, err
+	}
+	logger.Infof("Linking %s to %s", link, target)
+	if err := os.Symlink(target, link); err != nil {
+		_ = os.RemoveAll(location)
+		return nil, fmt.Errorf("failed to link %s: %w", target, err)
+	}
+
+	return &core.InstalledVersion{
+		Version:  goversion.Must(goversion.NewVersion(dirName)),
+		Origin:   dirName,
+		Location: location,
+	}, nil
 }
@@ -0,0 +1,138 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package languages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/testutil"
+	"github.com/toodofun/gvm/internal/util/env"
+)
+
+type fakeLanguage struct {
+	binPath string
+}
+
+func (f *fakeLanguage) Name() string {
+	return "fake"
+}
+
+func (f *fakeLanguage) ListRemoteVersions(ctx context.Context) ([]*core.RemoteVersion, error) {
+	return nil, nil
+}
+
+func (f *fakeLanguage) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVersion, error) {
+	return NewLanguage(f).ListInstalledVersions(ctx, f.binPath)
+}
+
+func (f *fakeLanguage) SetDefaultVersion(ctx context.Context, version string) error {
+	return NewLanguage(f).SetDefaultVersion(ctx, version, nil)
+}
+
+func (f *fakeLanguage) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
+	return NewLanguage(f).GetDefaultVersion()
+}
+
+func (f *fakeLanguage) Install(ctx context.Context, remoteVersion *core.RemoteVersion) error {
+	return nil
+}
+
+func (f *fakeLanguage) Uninstall(ctx context.Context, version string) error {
+	return NewLanguage(f).Uninstall(ctx, version, nil)
+}
+
+func TestLanguage_LinkAndUninstall(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink test skipped on Windows")
+	}
+	testutil.SetRootDir(t)
+	ctx := context.Background()
+
+	target := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(target, "bin"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "bin", "fake"), []byte("#!/bin/sh"), 0755))
+
+	tests := []struct {
+		name    string
+		sub     string
+		binPath string
+	}{
+		{name: "direct", sub: "", binPath: "bin"},
+		{name: "nested", sub: "fake", binPath: filepath.Join("fake", "bin")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang := &fakeLanguage{binPath: tt.binPath}
+			iv, err := NewLanguage(lang).Link(ctx, tt.name, target, tt.sub, goversion.Must(goversion.NewVersion("1.2.3")))
+			require.NoError(t, err)
+			assert.Equal(t, "1.2.3+"+tt.name, iv.Version.String())
+
+			_, err = NewLanguage(lang).Link(ctx, tt.name, target, tt.sub, goversion.Must(goversion.NewVersion("1.2.3")))
+			assert.Error(t, err, "linking the same name twice should fail")
+
+			installed, err := lang.ListInstalledVersions(ctx)
+			require.NoError(t, err)
+			require.Len(t, installed, 1)
+			assert.Equal(t, iv.Version.String(), installed[0].Version.String())
+
+			require.NoError(t, lang.Uninstall(ctx, iv.Version.String()))
+			assert.NoFileExists(t, iv.Location)
+			assert.FileExists(t, filepath.Join(target, "bin", "fake"), "uninstall must not touch the link target")
+		})
+	}
+}
+
+func TestLanguage_LinkInvalidName(t *testing.T) {
+	testutil.SetRootDir(t)
+	_, err := NewLanguage(&fakeLanguage{}).
+		Link(context.Background(), "bad_name", t.TempDir(), "", goversion.Must(goversion.NewVersion("1.2.3")))
+	assert.Error(t, err)
+}
+
+func TestLanguage_UninstallCleansDefault(t *testing.T) {
+	root := testutil.SetRootDir(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	ctx := context.Background()
+
+	lang := &fakeLanguage{binPath: "bin"}
+	for _, v := range []string{"1.0.0", "2.0.0"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "fake", v, "bin"), 0755))
+	}
+	envs := []env.KV{{Key: "PATH", Value: filepath.Join(root, "fake", "current", "bin"), Append: true}}
+	require.NoError(t, NewLanguage(lang).SetDefaultVersion(ctx, "2.0.0", envs))
+
+	require.NoError(t, NewLanguage(lang).Uninstall(ctx, "1.0.0", envs))
+	assert.Equal(t, "2.0.0", lang.GetDefaultVersion(ctx).Version.String(), "removing another version keeps the default")
+
+	require.NoError(t, NewLanguage(lang).Uninstall(ctx, "2.0.0", envs))
+	_, err := os.Lstat(filepath.Join(root, "fake", "current"))
+	assert.True(t, os.IsNotExist(err), "current symlink should be removed")
+
+	if runtime.GOOS != "windows" {
+		data, err := os.ReadFile(filepath.Join(home, ".gvmrc"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "PATH")
+	}
+}
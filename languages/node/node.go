@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"regexp"
 
 	"github.com/toodofun/gvm/i18n"
 
@@ -27,12 +28,14 @@ import (
 	"github.com/toodofun/gvm/internal/log"
 	common "github.com/toodofun/gvm/internal/util/compress"
 	"github.com/toodofun/gvm/internal/util/env"
+	"github.com/toodofun/gvm/internal/util/eol"
 	"github.com/toodofun/gvm/internal/util/path"
 	"github.com/toodofun/gvm/internal/util/slice"
 
 	"os"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/toodofun/gvm/languages"
 
@@ -121,18 +124,21 @@ func (n *Node) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVers
 }
 
 func (n *Node) SetDefaultVersion(ctx context.Context, version string) error {
+	return languages.NewLanguage(n).SetDefaultVersion(ctx, version, n.Envs())
+}
+
+func (n *Node) Envs() []env.KV {
 	binPath := filepath.Join(path.GetLangRoot(n.Name()), path.Current, "node", "bin")
 	if runtime.GOOS == "windows" {
 		binPath = filepath.Join(path.GetLangRoot(n.Name()), path.Current, "node")
 	}
-	envs := []env.KV{
+	return []env.KV{
 		{
 			Key:    "PATH",
 			Value:  binPath,
 			Append: true,
 		},
 	}
-	return languages.NewLanguage(n).SetDefaultVersion(ctx, version, envs)
 }
 
 func (n *Node) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
@@ -140,7 +146,15 @@ func (n *Node) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
 }
 
 func (n *Node) Uninstall(ctx context.Context, version string) error {
-	return languages.NewLanguage(n).Uninstall(version)
+	return languages.NewLanguage(n).Uninstall(ctx, version, n.Envs())
+}
+
+func (n *Node) UnsetDefaultVersion(ctx context.Context) error {
+	return languages.NewLanguage(n).UnsetDefaultVersion(ctx, n.Envs())
+}
+
+func (n *Node) Executable() string {
+	return "node"
 }
 
 func (n *Node) Install(ctx context.Context, version *core.RemoteVersion) error {
@@ -202,7 +216,40 @@ func (n *Node) Install(ctx context.Context, version *core.RemoteVersion) error {
 	if err = unPackage(ctx, file, name, version.Version.String()); err != nil {
 		return err
 	}
-	logger.Infof(
+	installComplete(ctx, version)
+	return nil
+}
+
+func (n *Node) InstallFromFile(ctx context.Context, version *core.RemoteVersion, file string) error {
+	if err, exist := languages.HasInstall(ctx, n, *version.Version); err != nil || exist {
+		return err
+	}
+	name := filepath.Base(file)
+	if !languages.AllSuffix.Has(name) {
+		return fmt.Errorf("unsupported package: %s", name)
+	}
+	log.GetLogger(ctx).Infof("📁 解压 Node.js 安装包...")
+	if err := unPackage(ctx, file, name, version.Version.String()); err != nil {
+		languages.NewLanguage(n).CleanVersion(ctx, version.Version.String())
+		return err
+	}
+	installComplete(ctx, version)
+	return nil
+}
+
+func (n *Node) InstallFromDir(ctx context.Context, version *core.RemoteVersion, dir string) error {
+	if err, exist := languages.HasInstall(ctx, n, *version.Version); err != nil || exist {
+		return err
+	}
+	if err := languages.NewLanguage(n).InstallFromDir(ctx, version.Version.String(), dir, lang); err != nil {
+		return err
+	}
+	installComplete(ctx, version)
+	return nil
+}
+
+func installComplete(ctx context.Context, version *core.RemoteVersion) {
+	log.GetLogger(ctx).Infof(
 		"✅ %s",
 		i18n.GetTranslate("languages.installComplete", map[string]any{
 			"lang":     lang,
@@ -210,7 +257,6 @@ func (n *Node) Install(ctx context.Context, version *core.RemoteVersion) error {
 			"location": filepath.Join(core.GetRootDir(), lang, version.Version.String(), lang, "bin"),
 		}),
 	)
-	return nil
 }
 
 func unPackage(ctx context.Context, file, packageName, version string) error {
@@ -271,6 +317,54 @@ func getPackageName(nodeInfo *Version, version *core.RemoteVersion) (string, err
 	return fmt.Sprintf("node-%s-%s", version.Origin, packageType), nil
 }
 
+var versionPattern = regexp.MustCompile(`v(\d+\.\d+\.\d+)`)
+
+// Link 登记外部安装的 Node.js，target 为包含 bin/node 的安装目录
+func (n *Node) Link(ctx context.Context, name, target string, version *goversion.Version) (*core.InstalledVersion, error) {
+	if version == nil {
+		bin := filepath.Join(target, "bin", "node")
+		if runtime.GOOS == env.RuntimeFromWindows {
+			bin = filepath.Join(target, "node")
+		}
+		var err error
+		if version, err = languages.DetectVersion(ctx, bin, []string{"--version"}, versionPattern); err != nil {
+			return nil, err
+		}
+	}
+	return languages.NewLanguage(n).Link(ctx, name, target, lang, version)
+}
+
+// scheduleURL 为 Node.js 官方发布计划，记录每个主版本的维护截止日期
+const scheduleURL = "https://raw.githubusercontent.com/nodejs/Release/main/schedule.json"
+
+func (n *Node) FetchLifecycles(ctx context.Context) ([]*core.Lifecycle, error) {
+	body, err := http.Default().Get(ctx, scheduleURL)
+	if err != nil {
+		return nil, err
+	}
+	return parseSchedule(body)
+}
+
+// parseSchedule 解析 schedule.json，键为 "v18"、"v0.12" 形式的版本线
+func parseSchedule(body []byte) ([]*core.Lifecycle, error) {
+	schedule := make(map[string]struct {
+		End string `json:"end"`
+	})
+	if err := json.Unmarshal(body, &schedule); err != nil {
+		return nil, err
+	}
+	res := make([]*core.Lifecycle, 0, len(schedule))
+	for key, v := range schedule {
+		end, err := time.Parse(time.DateOnly, v.End)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse end date of %s: %w", key, err)
+		}
+		res = append(res, &core.Lifecycle{Cycle: strings.TrimPrefix(key, "v"), EOL: end})
+	}
+	eol.Sort(res)
+	return res, nil
+}
+
 func init() {
 	core.RegisterLanguage(NewNode(defaultBaseURL, core.GetRootDir()))
 }
@@ -19,6 +19,7 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/toodofun/gvm/internal/core"
 
@@ -83,3 +84,18 @@ func equalVersion(execPath, expectedVersion string, expectedErr error, t *testin
 	version := strings.TrimSpace(string(output))
 	require.Equal(t, expectedVersion, version)
 }
+
+func TestParseSchedule(t *testing.T) {
+	body := []byte(`{
+		"v18": {"start": "2022-04-19", "lts": "2022-10-25", "end": "2025-04-30", "codename": "Hydrogen"},
+		"v0.12": {"start": "2015-02-06", "end": "2016-12-31"},
+		"v9": {"start": "2017-10-01", "end": "2018-06-30"}
+	}`)
+	cycles, err := parseSchedule(body)
+	require.NoError(t, err)
+	require.Len(t, cycles, 3)
+	require.Equal(t, "0.12", cycles[0].Cycle)
+	require.Equal(t, "9", cycles[1].Cycle)
+	require.Equal(t, "18", cycles[2].Cycle)
+	require.Equal(t, "2025-04-30", cycles[2].EOL.Format(time.DateOnly))
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gvm-plugin-hello 插件协议的参考实现，安装的 hello 命令会输出自己的版本号。
+// 放到 PATH 或 gvm 根目录后即可使用 gvm install hello 1.1.0
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/toodofun/gvm/languages/plugin"
+)
+
+type hello struct{}
+
+func (h *hello) Name() string {
+	return "hello"
+}
+
+func (h *hello) Executable() string {
+	return "hello"
+}
+
+func (h *hello) ListRemote(ctx context.Context) ([]plugin.RemoteVersion, error) {
+	return []plugin.RemoteVersion{
+		{Version: "1.0.0"},
+		{Version: "1.1.0"},
+		{Version: "2.0.0-rc.1", Comment: "Prerelease"},
+	}, nil
+}
+
+// Install 在 Dir/bin 中生成 hello 脚本，真实的插件通常在这里下载并解压安装包
+func (h *hello) Install(ctx context.Context, params plugin.InstallParams) error {
+	// 标准错误会显示给用户
+	_, _ = fmt.Fprintf(os.Stderr, "installing hello %s\n", params.Version)
+	bin := filepath.Join(params.Dir, "bin")
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		return err
+	}
+	if runtime.GOOS == "windows" {
+		return os.WriteFile(filepath.Join(bin, "hello.cmd"), []byte("@echo hello "+params.Version+"\r\n"), 0755)
+	}
+	return os.WriteFile(filepath.Join(bin, "hello"), []byte("#!/bin/sh\necho hello "+params.Version+"\n"), 0755)
+}
+
+func (h *hello) Env(ctx context.Context, params plugin.EnvParams) (*plugin.EnvResult, error) {
+	return &plugin.EnvResult{
+		Path: []string{filepath.Join(params.Dir, "bin")},
+		Env:  map[string]string{"HELLO_HOME": params.Dir},
+	}, nil
+}
+
+// Uninstall 没有目录之外的内容需要清理，只是演示可选的卸载钩子
+func (h *hello) Uninstall(ctx context.Context, params plugin.InstallParams) error {
+	_, _ = fmt.Fprintf(os.Stderr, "uninstalling hello %s\n", params.Version)
+	return nil
+}
+
+func main() {
+	if err := plugin.Serve(context.Background(), os.Stdin, os.Stdout, &hello{}); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
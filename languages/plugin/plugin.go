@@ -0,0 +1,354 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugin 通过标准输入输出上的 JSON 协议使用外部插件，插件是名为 gvm-plugin-<name> 的可执行文件，
+// 放在 gvm 根目录或 PATH 中，每个插件注册为一个语言
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/log"
+	"github.com/toodofun/gvm/internal/util/env"
+	"github.com/toodofun/gvm/internal/util/path"
+	"github.com/toodofun/gvm/languages"
+
+	"github.com/duke-git/lancet/v2/slice"
+	goversion "github.com/hashicorp/go-version"
+)
+
+// Prefix 插件可执行文件名的前缀
+const Prefix = "gvm-plugin-"
+
+// detachedTimeout 接口中没有 ctx 的方法（Executable、Envs）调用插件时的超时时间
+const detachedTimeout = 30 * time.Second
+
+// Plugin 外部插件实现的语言
+type Plugin struct {
+	name string
+	path string
+
+	// mu 保护 desc 和 envs，两者都只缓存成功的结果
+	mu   sync.Mutex
+	desc *Description
+	envs []env.KV
+}
+
+// New 创建插件语言，path 为插件可执行文件
+func New(name, path string) *Plugin {
+	return &Plugin{name: name, path: path}
+}
+
+// Path 返回插件可执行文件的路径
+func (p *Plugin) Path() string {
+	return p.path
+}
+
+// call 启动插件处理一次请求，插件的标准错误输出到日志，失败时附在错误信息中
+func (p *Plugin) call(ctx context.Context, method string, params, result any) error {
+	req := &Request{Protocol: ProtocolVersion, Method: method}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		req.Params = data
+	}
+	input, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	var stdout, stderr bytes.Buffer
+	logWriter := log.GetStderr(ctx)
+	if closer, ok := logWriter.(io.Closer); ok {
+		defer func() { _ = closer.Close() }()
+	}
+	cmd := exec.CommandContext(ctx, p.path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = io.MultiWriter(&stderr, logWriter)
+	runErr := cmd.Run()
+
+	resp := new(Response)
+	if err := json.Unmarshal(stdout.Bytes(), resp); err != nil {
+		if runErr != nil {
+			return fmt.Errorf("plugin %s %s failed: %w: %s", p.name, method, runErr, strings.TrimSpace(stderr.String()))
+		}
+		return fmt.Errorf("plugin %s %s returned an invalid response: %w", p.name, method, err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("plugin %s %s: %s", p.name, method, resp.Error)
+	}
+	if resp.Protocol != ProtocolVersion {
+		return fmt.Errorf("plugin %s speaks protocol %d, gvm supports %d", p.name, resp.Protocol, ProtocolVersion)
+	}
+	if runErr != nil {
+		return fmt.Errorf("plugin %s %s failed: %w", p.name, method, runErr)
+	}
+	if result != nil {
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return fmt.Errorf("plugin %s %s returned an invalid result: %w", p.name, method, err)
+		}
+	}
+	return nil
+}
+
+// Describe 返回插件的基本信息，只缓存成功的结果，调用失败（如 ctx 被取消）后下次会重新请求插件
+func (p *Plugin) Describe(ctx context.Context) (*Description, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.desc != nil {
+		return p.desc, nil
+	}
+	desc := new(Description)
+	if err := p.call(ctx, MethodDescribe, nil, desc); err != nil {
+		return nil, err
+	}
+	p.desc = desc
+	return desc, nil
+}
+
+// supports 判断插件是否声明了可选方法
+func (p *Plugin) supports(ctx context.Context, method string) bool {
+	desc, err := p.Describe(ctx)
+	return err == nil && slice.Contain(desc.Methods, method)
+}
+
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// Executable 返回插件声明的可执行文件名，插件无法使用时为插件名称
+func (p *Plugin) Executable() string {
+	ctx, cancel := context.WithTimeout(context.Background(), detachedTimeout)
+	defer cancel()
+	if desc, err := p.Describe(ctx); err == nil && desc.Executable != "" {
+		return desc.Executable
+	}
+	return p.name
+}
+
+func (p *Plugin) ListRemoteVersions(ctx context.Context) ([]*core.RemoteVersion, error) {
+	logger := log.GetLogger(ctx)
+	if _, err := p.Describe(ctx); err != nil {
+		return nil, err
+	}
+	versions := make([]RemoteVersion, 0)
+	if err := p.call(ctx, MethodListRemote, nil, &versions); err != nil {
+		return nil, err
+	}
+
+	res := make([]*core.RemoteVersion, 0, len(versions))
+	for _, v := range versions {
+		ver, err := goversion.NewVersion(strings.TrimPrefix(v.Version, "v"))
+		if err != nil {
+			logger.Warnf("Failed to parse version %s: %v", v.Version, err)
+			continue
+		}
+		comment := v.Comment
+		if comment == "" {
+			comment = "Stable Release"
+		}
+		res = append(res, &core.RemoteVersion{Version: ver, Origin: v.Version, Comment: comment})
+	}
+	return res, nil
+}
+
+func (p *Plugin) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVersion, error) {
+	return languages.NewLanguage(p).ListInstalledVersions(ctx, "")
+}
+
+func (p *Plugin) Install(ctx context.Context, remoteVersion *core.RemoteVersion) error {
+	logger := log.GetLogger(ctx)
+	logger.Infof("Install remote version %s", remoteVersion.Origin)
+	if err, exist := languages.HasInstall(ctx, p, *remoteVersion.Version); err != nil || exist {
+		return err
+	}
+	if _, err := p.Describe(ctx); err != nil {
+		return err
+	}
+
+	dest := filepath.Join(path.GetLangRoot(p.Name()), remoteVersion.Version.String())
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	if err := p.call(ctx, MethodInstall, &InstallParams{Version: remoteVersion.Origin, Dir: dest}, nil); err != nil {
+		if rmErr := os.RemoveAll(dest); rmErr != nil {
+			logger.Warnf("Failed to clean %s: %v", dest, rmErr)
+		}
+		return fmt.Errorf("failed to install version %s: %w", remoteVersion.Version.String(), err)
+	}
+	logger.Infof("Version %s was successfully installed in %s", remoteVersion.Version.String(), dest)
+	return nil
+}
+
+// Envs 由插件给出 PATH 和环境变量，插件无法使用时只把 current/bin 加入 PATH
+func (p *Plugin) Envs() []env.KV {
+	ctx, cancel := context.WithTimeout(context.Background(), detachedTimeout)
+	defer cancel()
+	return p.envsOf(ctx)
+}
+
+func (p *Plugin) envsOf(ctx context.Context) []env.KV {
+	p.mu.Lock()
+	cached := p.envs
+	p.mu.Unlock()
+	if cached != nil {
+		return cached
+	}
+
+	current := filepath.Join(path.GetLangRoot(p.Name()), path.Current)
+	result := new(EnvResult)
+	if _, err := p.Describe(ctx); err != nil {
+		return []env.KV{{Key: "PATH", Value: filepath.Join(current, "bin"), Append: true}}
+	}
+	if err := p.call(ctx, MethodEnv, &EnvParams{Dir: current}, result); err != nil {
+		log.GetLogger(ctx).Warnf("%v", err)
+		return []env.KV{{Key: "PATH", Value: filepath.Join(current, "bin"), Append: true}}
+	}
+
+	envs := make([]env.KV, 0, len(result.Path)+len(result.Env))
+	for _, dir := range result.Path {
+		envs = append(envs, env.KV{Key: "PATH", Value: dir, Append: true})
+	}
+	keys := make([]string, 0, len(result.Env))
+	for k := range result.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		envs = append(envs, env.KV{Key: k, Value: result.Env[k]})
+	}
+	p.mu.Lock()
+	p.envs = envs
+	p.mu.Unlock()
+	return envs
+}
+
+func (p *Plugin) SetDefaultVersion(ctx context.Context, version string) error {
+	return languages.NewLanguage(p).SetDefaultVersion(ctx, version, p.envsOf(ctx))
+}
+
+func (p *Plugin) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
+	return languages.NewLanguage(p).GetDefaultVersion()
+}
+
+// Uninstall 插件声明了 uninstall 时先调用插件清理，再删除版本目录
+func (p *Plugin) Uninstall(ctx context.Context, version string) error {
+	if p.supports(ctx, MethodUninstall) {
+		dir := filepath.Join(path.GetLangRoot(p.Name()), version)
+		if err := p.call(ctx, MethodUninstall, &InstallParams{Version: version, Dir: dir}, nil); err != nil {
+			return err
+		}
+	}
+	return languages.NewLanguage(p).Uninstall(ctx, version, p.envsOf(ctx))
+}
+
+func (p *Plugin) UnsetDefaultVersion(ctx context.Context) error {
+	return languages.NewLanguage(p).UnsetDefaultVersion(ctx, p.envsOf(ctx))
+}
+
+// Discover 查找插件，返回插件名称到可执行文件的映射；gvm 根目录优先，其次按 PATH 的顺序，同名时使用先找到的
+func Discover() map[string]string {
+	res := make(map[string]string)
+	dirs := append([]string{core.GetRootDir()}, filepath.SplitList(os.Getenv("PATH"))...)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := strings.CutPrefix(e.Name(), Prefix)
+			if !ok || name == "" {
+				continue
+			}
+			file := filepath.Join(dir, e.Name())
+			if !isExecutable(file) {
+				continue
+			}
+			if runtime.GOOS == env.RuntimeFromWindows {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			if _, exists := res[name]; !exists {
+				res[name] = file
+			}
+		}
+	}
+	return res
+}
+
+func isExecutable(file string) bool {
+	info, err := os.Stat(file)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == env.RuntimeFromWindows {
+		return strings.EqualFold(filepath.Ext(file), ".exe")
+	}
+	return info.Mode()&0111 != 0
+}
+
+var (
+	loaded     = make([]*Plugin, 0)
+	loadErrors = make(map[string]error)
+)
+
+// Load 将 Discover 找到的可执行文件注册为语言，此时不会启动插件
+func Load() {
+	loaded = make([]*Plugin, 0)
+	loadErrors = make(map[string]error)
+	found := Discover()
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := core.ValidateName(name); err != nil {
+			loadErrors[found[name]] = err
+			continue
+		}
+		if _, exists := core.GetLanguage(name); exists {
+			loadErrors[found[name]] = fmt.Errorf("name %s conflicts with a registered language", name)
+			continue
+		}
+		p := New(name, found[name])
+		loaded = append(loaded, p)
+		core.RegisterLanguage(p)
+	}
+}
+
+// Loaded 返回最近一次 Load 中注册的插件
+func Loaded() []*Plugin {
+	return loaded
+}
+
+// LoadErrors 返回最近一次 Load 中没有注册的插件及原因
+func LoadErrors() map[string]error {
+	return loadErrors
+}
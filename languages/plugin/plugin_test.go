@@ -0,0 +1,184 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/testutil"
+	"github.com/toodofun/gvm/internal/util/env"
+)
+
+// buildHello 将参考插件编译到 dir 中
+func buildHello(t *testing.T, dir string) string {
+	t.Helper()
+	name := Prefix + "hello"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	bin := filepath.Join(dir, name)
+	out, err := exec.Command("go", "build", "-o", bin, "./gvm-plugin-hello").CombinedOutput()
+	require.NoError(t, err, string(out))
+	return bin
+}
+
+func TestPlugin(t *testing.T) {
+	root := testutil.SetRootDir(t)
+	bin := buildHello(t, root)
+	t.Setenv("PATH", "")
+	ctx := context.Background()
+
+	// 名称不合法的插件不会注册
+	invalid := filepath.Join(root, Prefix+".hidden"+filepath.Ext(bin))
+	require.NoError(t, os.Link(bin, invalid))
+	// 与 gvm 根目录下已有条目重名的插件也不会注册
+	reserved := filepath.Join(root, Prefix+"config.json"+filepath.Ext(bin))
+	require.NoError(t, os.Link(bin, reserved))
+
+	Load()
+	require.Len(t, LoadErrors(), 2)
+	assert.ErrorContains(t, LoadErrors()[invalid], "invalid name")
+	assert.ErrorContains(t, LoadErrors()[reserved], "reserved by gvm")
+	require.Len(t, Loaded(), 1)
+	assert.Equal(t, bin, Loaded()[0].Path())
+	lang, exists := core.GetLanguage("hello")
+	require.True(t, exists)
+	p := lang.(*Plugin)
+
+	desc, err := p.Describe(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, desc.Methods, MethodUninstall)
+	assert.Equal(t, "hello", p.Executable())
+
+	versions, err := p.ListRemoteVersions(ctx)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, "Stable Release", versions[0].Comment)
+	assert.Equal(t, "Prerelease", versions[2].Comment)
+
+	require.NoError(t, p.Install(ctx, versions[1]))
+	installed, err := p.ListInstalledVersions(ctx)
+	require.NoError(t, err)
+	require.Len(t, installed, 1)
+	assert.Equal(t, "1.1.0", installed[0].Version.String())
+	if runtime.GOOS != "windows" {
+		out, err := exec.Command(filepath.Join(root, "hello", "1.1.0", "bin", "hello")).Output()
+		require.NoError(t, err)
+		assert.Equal(t, "hello 1.1.0\n", string(out))
+	}
+
+	// 调用方取消 ctx 时退回 current/bin，结果不会被缓存
+	current := filepath.Join(root, "hello", "current")
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Equal(t, []env.KV{{Key: "PATH", Value: filepath.Join(current, "bin"), Append: true}}, p.envsOf(cancelled))
+	envs := p.Envs()
+	require.Len(t, envs, 2)
+	assert.Equal(t, filepath.Join(current, "bin"), envs[0].Value)
+	assert.True(t, envs[0].Append)
+	assert.Equal(t, "HELLO_HOME", envs[1].Key)
+	assert.Equal(t, current, envs[1].Value)
+
+	require.NoError(t, p.Uninstall(ctx, "1.1.0"))
+	assert.NoDirExists(t, filepath.Join(root, "hello", "1.1.0"))
+
+	// 再次加载时名称与已注册的语言冲突
+	Load()
+	assert.ErrorContains(t, LoadErrors()[bin], "conflicts with a registered language")
+}
+
+func TestPluginErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on windows")
+	}
+	dir := t.TempDir()
+	script := func(name, body string) *Plugin {
+		file := filepath.Join(dir, Prefix+name)
+		require.NoError(t, os.WriteFile(file, []byte("#!/bin/sh\n"+body), 0755))
+		return New(name, file)
+	}
+	ctx := context.Background()
+
+	_, err := script("crash", "echo boom >&2\nexit 3\n").Describe(ctx)
+	assert.ErrorContains(t, err, "boom")
+	_, err = script("future", `echo '{"protocol": 2, "result": {}}'`).Describe(ctx)
+	assert.ErrorContains(t, err, "speaks protocol 2, gvm supports 1")
+	_, err = script("failing", `echo '{"protocol": 1, "error": "not today"}'`).Describe(ctx)
+	assert.ErrorContains(t, err, "not today")
+	assert.Equal(t, "failing", New("failing", filepath.Join(dir, Prefix+"failing")).Executable())
+
+	// 失败的结果不会被缓存，第一次调用失败后再次调用会重新请求插件
+	marker := filepath.Join(dir, "flaky-called")
+	flaky := script("flaky", fmt.Sprintf(`if [ ! -f %[1]s ]; then touch %[1]s; exit 1; fi
+echo '{"protocol": 1, "result": {"name": "flaky", "executable": "flaky-bin"}}'
+`, marker))
+	_, err = flaky.Describe(ctx)
+	assert.Error(t, err)
+	desc, err := flaky.Describe(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "flaky-bin", desc.Executable)
+
+	// 没有执行权限的文件不会被发现
+	require.NoError(t, os.WriteFile(filepath.Join(dir, Prefix+"plain"), nil, 0644))
+	t.Setenv("PATH", dir)
+	found := Discover()
+	assert.Contains(t, found, "crash")
+	assert.NotContains(t, found, "plain")
+}
+
+func TestServe(t *testing.T) {
+	serve := func(req string) *Response {
+		var out bytes.Buffer
+		require.NoError(t, Serve(context.Background(), strings.NewReader(req), &out, &fakeHandler{}))
+		resp := new(Response)
+		require.NoError(t, json.Unmarshal(out.Bytes(), resp))
+		return resp
+	}
+
+	resp := serve(`{"protocol": 1, "method": "describe"}`)
+	assert.Empty(t, resp.Error)
+	assert.JSONEq(t, `{"name": "fake", "executable": "fake", "methods": ["describe", "list-remote", "install", "env"]}`,
+		string(resp.Result))
+
+	resp = serve(`{"protocol": 2, "method": "describe"}`)
+	assert.Equal(t, "unsupported protocol 2, this plugin speaks 1", resp.Error)
+	resp = serve(`{"protocol": 1, "method": "uninstall"}`)
+	assert.Equal(t, "unsupported method uninstall", resp.Error)
+}
+
+type fakeHandler struct{}
+
+func (f *fakeHandler) Name() string       { return "fake" }
+func (f *fakeHandler) Executable() string { return "fake" }
+func (f *fakeHandler) ListRemote(ctx context.Context) ([]RemoteVersion, error) {
+	return nil, nil
+}
+func (f *fakeHandler) Install(ctx context.Context, params InstallParams) error { return nil }
+func (f *fakeHandler) Env(ctx context.Context, params EnvParams) (*EnvResult, error) {
+	return &EnvResult{}, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ProtocolVersion gvm 使用的插件协议版本，协议不兼容地修改时递增
+const ProtocolVersion = 1
+
+// 协议中的方法，每次调用启动一次插件进程，从标准输入读取一个 Request，向标准输出写入一个 Response；
+// 标准错误作为日志显示给用户
+const (
+	// MethodDescribe 返回 Description，协议版本不一致时 gvm 拒绝使用该插件
+	MethodDescribe = "describe"
+	// MethodListRemote 返回 []RemoteVersion
+	MethodListRemote = "list-remote"
+	// MethodInstall 参数为 InstallParams，将版本安装到 Dir，Dir 由 gvm 创建，失败时由 gvm 删除
+	MethodInstall = "install"
+	// MethodEnv 参数为 EnvParams，返回 EnvResult
+	MethodEnv = "env"
+	// MethodUninstall 可选，参数为 InstallParams，gvm 删除版本目录之前调用，用于清理目录之外的内容
+	MethodUninstall = "uninstall"
+)
+
+// Request gvm 发送给插件的请求
+type Request struct {
+	Protocol int             `json:"protocol"`
+	Method   string          `json:"method"`
+	Params   json.RawMessage `json:"params,omitempty"`
+}
+
+// Response 插件返回的结果，Error 不为空时表示调用失败
+type Response struct {
+	Protocol int             `json:"protocol"`
+	Result   json.RawMessage `json:"result,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// Description 插件的基本信息，Methods 为插件支持的方法
+type Description struct {
+	Name       string   `json:"name"`
+	Executable string   `json:"executable"`
+	Methods    []string `json:"methods"`
+}
+
+// RemoteVersion 可安装的版本，Version 同时作为安装时的 version 参数
+type RemoteVersion struct {
+	Version string `json:"version"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// InstallParams 安装、卸载的参数，Dir 为版本目录的绝对路径
+type InstallParams struct {
+	Version string `json:"version"`
+	Dir     string `json:"dir"`
+}
+
+// EnvParams 环境变量的参数，Dir 为当前版本目录（current 软链接）的绝对路径
+type EnvParams struct {
+	Dir string `json:"dir"`
+}
+
+// EnvResult 需要加入 PATH 的目录和需要设置的环境变量，路径应基于 EnvParams.Dir
+type EnvResult struct {
+	Path []string          `json:"path"`
+	Env  map[string]string `json:"env,omitempty"`
+}
+
+// Handler 使用 Go 编写插件时需要实现的方法，Name、Executable 会作为 Description 返回
+type Handler interface {
+	Name() string
+	Executable() string
+	ListRemote(ctx context.Context) ([]RemoteVersion, error)
+	Install(ctx context.Context, params InstallParams) error
+	Env(ctx context.Context, params EnvParams) (*EnvResult, error)
+}
+
+// Uninstaller 可选接口，实现后插件会声明 uninstall 方法
+type Uninstaller interface {
+	Uninstall(ctx context.Context, params InstallParams) error
+}
+
+// Serve 处理一次请求，供 Go 编写的插件在 main 中调用，如 plugin.Serve(ctx, os.Stdin, os.Stdout, h)
+func Serve(ctx context.Context, in io.Reader, out io.Writer, h Handler) error {
+	req := new(Request)
+	if err := json.NewDecoder(in).Decode(req); err != nil {
+		return fmt.Errorf("failed to read request: %w", err)
+	}
+	result, err := handle(ctx, req, h)
+	resp := &Response{Protocol: ProtocolVersion}
+	if err != nil {
+		resp.Error = err.Error()
+	} else if resp.Result, err = json.Marshal(result); err != nil {
+		return err
+	}
+	return json.NewEncoder(out).Encode(resp)
+}
+
+func handle(ctx context.Context, req *Request, h Handler) (any, error) {
+	if req.Protocol != ProtocolVersion {
+		return nil, fmt.Errorf("unsupported protocol %d, this plugin speaks %d", req.Protocol, ProtocolVersion)
+	}
+	switch req.Method {
+	case MethodDescribe:
+		desc := &Description{
+			Name:       h.Name(),
+			Executable: h.Executable(),
+			Methods:    []string{MethodDescribe, MethodListRemote, MethodInstall, MethodEnv},
+		}
+		if _, ok := h.(Uninstaller); ok {
+			desc.Methods = append(desc.Methods, MethodUninstall)
+		}
+		return desc, nil
+	case MethodListRemote:
+		return h.ListRemote(ctx)
+	case MethodInstall:
+		params := InstallParams{}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, h.Install(ctx, params)
+	case MethodEnv:
+		params := EnvParams{}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, err
+		}
+		return h.Env(ctx, params)
+	case MethodUninstall:
+		if u, ok := h.(Uninstaller); ok {
+			params := InstallParams{}
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				return nil, err
+			}
+			return nil, u.Uninstall(ctx, params)
+		}
+	}
+	return nil, fmt.Errorf("unsupported method %s", req.Method)
+}
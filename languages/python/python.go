@@ -16,12 +16,14 @@ package python
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/toodofun/gvm/i18n"
 
@@ -30,12 +32,15 @@ import (
 	"github.com/toodofun/gvm/internal/log"
 	"github.com/toodofun/gvm/internal/util/compress"
 	"github.com/toodofun/gvm/internal/util/env"
+	"github.com/toodofun/gvm/internal/util/eol"
 	"github.com/toodofun/gvm/internal/util/path"
 	"github.com/toodofun/gvm/languages"
+	"github.com/toodofun/gvm/languages/dirindex"
 
 	"os/exec"
 	"runtime"
 
+	"github.com/duke-git/lancet/v2/slice"
 	goversion "github.com/hashicorp/go-version"
 )
 
@@ -44,6 +49,9 @@ const (
 	baseUrl = "https://www.python.org/ftp/python/"
 )
 
+// pythonIndex python.org/ftp 的目录索引，每个版本一个目录，如 3.8.19/
+var pythonIndex = &dirindex.Index{URL: baseUrl, Pattern: regexp.MustCompile(`^([0-9]+\.[0-9]+\.[0-9]+)/$`)}
+
 type Python struct{}
 
 type Version struct {
@@ -109,25 +117,16 @@ func (p *Python) ListRemoteVersions(ctx context.Context) ([]*core.RemoteVersion,
 	logger := log.GetLogger(ctx)
 	res := make([]*core.RemoteVersion, 0)
 
-	body, err := gvmhttp.Default().Get(ctx, baseUrl)
+	dirs, err := pythonIndex.Versions(ctx)
 	if err != nil {
 		logger.Warnf("Failed to fetch python versions: %v", err)
 		return res, err
 	}
 
-	// 匹配如 <a href="3.8.19/">3.8.19/</a> 这样的目录
-	re := regexp.MustCompile(`<a href="([0-9]+\.[0-9]+\.[0-9]+)/">`)
-	matches := re.FindAllStringSubmatch(string(body), -1)
-	versionSet := make(map[string]struct{})
-
 	// 收集所有版本
-	versions := make([]string, 0)
-	for _, m := range matches {
-		verStr := m[1]
-		if _, ok := versionSet[verStr]; !ok {
-			versions = append(versions, verStr)
-			versionSet[verStr] = struct{}{}
-		}
+	versions := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		versions = append(versions, d.Name)
 	}
 
 	// 对版本进行排序（从新到旧）
@@ -149,12 +148,11 @@ func (p *Python) ListRemoteVersions(ctx context.Context) ([]*core.RemoteVersion,
 
 		// 对于最新的几个版本，检查是否有候选版本
 		if checkedCount < checkLimit {
-			versionURL := fmt.Sprintf("%s%s/", baseUrl, verStr)
-			versionBody, err := gvmhttp.Default().Get(ctx, versionURL)
+			files, err := pythonIndex.Files(ctx, &dirindex.Version{Name: verStr, Dir: verStr + "/"})
 			if err == nil {
 				// 检查是否有稳定版本文件
-				hasStableRelease := strings.Contains(string(versionBody), fmt.Sprintf("Python-%s.tgz", verStr)) ||
-					strings.Contains(string(versionBody), fmt.Sprintf("Python-%s.tar.xz", verStr))
+				hasStableRelease := slice.Contain(files, fmt.Sprintf("Python-%s.tgz", verStr)) ||
+					slice.Contain(files, fmt.Sprintf("Python-%s.tar.xz", verStr))
 
 				if hasStableRelease {
 					res = append(res, &core.RemoteVersion{
@@ -164,15 +162,15 @@ func (p *Python) ListRemoteVersions(ctx context.Context) ([]*core.RemoteVersion,
 					})
 				} else {
 					// 查找候选版本
-					rcPattern := fmt.Sprintf(`Python-%s(a[0-9]+|b[0-9]+|rc[0-9]+)\.tar\.(gz|xz)`, regexp.QuoteMeta(verStr))
+					rcPattern := fmt.Sprintf(`^Python-%s(a[0-9]+|b[0-9]+|rc[0-9]+)\.tar\.(gz|xz)$`, regexp.QuoteMeta(verStr))
 					rcRe := regexp.MustCompile(rcPattern)
-					rcMatches := rcRe.FindAllStringSubmatch(string(versionBody), -1)
 
 					// 收集唯一的候选版本
 					rcVersions := make(map[string]bool)
-					for _, rcMatch := range rcMatches {
-						fullVersion := verStr + rcMatch[1]
-						rcVersions[fullVersion] = true
+					for _, file := range files {
+						if rcMatch := rcRe.FindStringSubmatch(file); rcMatch != nil {
+							rcVersions[verStr+rcMatch[1]] = true
+						}
 					}
 
 					// 添加候选版本
@@ -227,7 +225,11 @@ func (p *Python) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVe
 }
 
 func (p *Python) SetDefaultVersion(ctx context.Context, version string) error {
-	envs := []env.KV{
+	return languages.NewLanguage(p).SetDefaultVersion(ctx, version, p.Envs())
+}
+
+func (p *Python) Envs() []env.KV {
+	return []env.KV{
 		{
 			Key:    "PATH",
 			Value:  filepath.Join(path.GetLangRoot(p.Name()), path.Current, "bin"),
@@ -243,7 +245,6 @@ func (p *Python) SetDefaultVersion(ctx context.Context, version string) error {
 			Append: true,
 		},
 	}
-	return languages.NewLanguage(p).SetDefaultVersion(ctx, version, envs)
 }
 
 func (p *Python) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
@@ -251,28 +252,35 @@ func (p *Python) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
 }
 
 func (p *Python) Uninstall(ctx context.Context, version string) error {
-	return languages.NewLanguage(p).Uninstall(version)
+	return languages.NewLanguage(p).Uninstall(ctx, version, p.Envs())
+}
+
+func (p *Python) UnsetDefaultVersion(ctx context.Context) error {
+	return languages.NewLanguage(p).UnsetDefaultVersion(ctx, p.Envs())
+}
+
+func (p *Python) Executable() string {
+	return "python3"
 }
 
 // 检查指定版本目录下的可用文件（包括候选版本）
 func (p *Python) checkAvailableVersions(ctx context.Context, baseVersion string) ([]string, error) {
 	logger := log.GetLogger(ctx)
-	url := fmt.Sprintf("%s%s/", baseUrl, baseVersion)
-
-	body, err := gvmhttp.Default().Get(ctx, url)
+	files, err := pythonIndex.Files(ctx, &dirindex.Version{Name: baseVersion, Dir: baseVersion + "/"})
 	if err != nil {
 		logger.Debugf("Failed to fetch directory listing for %s: %v", baseVersion, err)
 		return nil, err
 	}
 
 	// 匹配所有 Python-X.Y.Z*.tgz 文件
-	pattern := fmt.Sprintf(`<a href="(Python-%s[^"]*\.tgz)"`, regexp.QuoteMeta(baseVersion))
+	pattern := fmt.Sprintf(`^Python-%s.*\.tgz$`, regexp.QuoteMeta(baseVersion))
 	re := regexp.MustCompile(pattern)
-	matches := re.FindAllStringSubmatch(string(body), -1)
 
 	var versions []string
-	for _, match := range matches {
-		filename := match[1]
+	for _, filename := range files {
+		if !re.MatchString(filename) {
+			continue
+		}
 		// 提取版本号部分（去掉 Python- 前缀和 .tgz 后缀）
 		version := strings.TrimPrefix(filename, "Python-")
 		version = strings.TrimSuffix(version, ".tgz")
@@ -370,7 +378,55 @@ func (p *Python) Install(ctx context.Context, version *core.RemoteVersion) error
 	if err = os.RemoveAll(file); err != nil {
 		logger.Warnf("Failed to clean %s: %v", file, err)
 	}
-	// 自动编译源码并安装到gvm管理目录
+	if err := p.build(ctx, srcDir, installRoot, version); err != nil {
+		return err
+	}
+	installComplete(ctx, version, installRoot)
+	return nil
+}
+
+func (p *Python) InstallFromFile(ctx context.Context, version *core.RemoteVersion, file string) error {
+	logger := log.GetLogger(ctx)
+	if err, exist := languages.HasInstall(ctx, p, *version.Version); err != nil || exist {
+		return err
+	}
+	installRoot := filepath.Join(path.GetLangRoot(lang), version.Version.String())
+	if strings.Contains(installRoot, " ") {
+		return fmt.Errorf("Python 源码包不支持带空格的安装路径，请将 gvm 根目录迁移到无空格路径（如 ~/.gvm）后重试")
+	}
+	logger.Infof("Extracting: %s", file)
+	if err := compress.Extract(ctx, file, installRoot); err != nil {
+		languages.NewLanguage(p).CleanVersion(ctx, version.Version.String())
+		return fmt.Errorf("failed to extract %s: %w", file, err)
+	}
+	// 源码包解压后的目录名与包名一致，如 Python-3.12.1.tgz -> Python-3.12.1
+	name := filepath.Base(file)
+	for _, suffix := range []string{".tgz", ".tar.gz", ".tar.xz", ".zip"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	if err := p.build(ctx, filepath.Join(installRoot, name), installRoot, version); err != nil {
+		languages.NewLanguage(p).CleanVersion(ctx, version.Version.String())
+		return err
+	}
+	installComplete(ctx, version, installRoot)
+	return nil
+}
+
+// InstallFromDir 复制已编译好的 Python 安装目录（包含 bin/python3）
+func (p *Python) InstallFromDir(ctx context.Context, version *core.RemoteVersion, dir string) error {
+	if err, exist := languages.HasInstall(ctx, p, *version.Version); err != nil || exist {
+		return err
+	}
+	if err := languages.NewLanguage(p).InstallFromDir(ctx, version.Version.String(), dir, ""); err != nil {
+		return err
+	}
+	installComplete(ctx, version, filepath.Join(path.GetLangRoot(lang), version.Version.String()))
+	return nil
+}
+
+// build 编译源码并安装到gvm管理目录
+func (p *Python) build(ctx context.Context, srcDir, installRoot string, version *core.RemoteVersion) error {
+	logger := log.GetLogger(ctx)
 	logger.Infof("🔨 准备编译 Python %s 源码...", version.Version.String())
 	logger.Infof("📍 编译目录: %s", srcDir)
 	logger.Infof("⚠️  注意: Python 源码编译可能需要 10-30 分钟，请耐心等待...")
@@ -468,7 +524,11 @@ func (p *Python) Install(ctx context.Context, version *core.RemoteVersion) error
 		}
 		logger.Infof("✅ 步骤 %d/3 完成: %s", i+1, cmdInfo.description)
 	}
-	logger.Infof(
+	return nil
+}
+
+func installComplete(ctx context.Context, version *core.RemoteVersion, installRoot string) {
+	log.GetLogger(ctx).Infof(
 		"✅ %s",
 		i18n.GetTranslate("languages.installComplete", map[string]any{
 			"lang":     lang,
@@ -476,7 +536,55 @@ func (p *Python) Install(ctx context.Context, version *core.RemoteVersion) error
 			"location": filepath.Join(installRoot, "bin"),
 		}),
 	)
-	return nil
+}
+
+var versionPattern = regexp.MustCompile(`Python (\d+\.\d+\.\d+\S*)`)
+
+// Link 登记外部安装的 Python，target 为包含 bin/python3 的安装前缀
+func (p *Python) Link(ctx context.Context, name, target string, version *goversion.Version) (*core.InstalledVersion, error) {
+	if version == nil {
+		var err error
+		version, err = languages.DetectVersion(ctx, filepath.Join(target, "bin", "python3"), []string{"--version"}, versionPattern)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return languages.NewLanguage(p).Link(ctx, name, target, "", version)
+}
+
+// releaseCycleURL 为 PEP 602 发布周期数据，记录每个版本线的 end_of_life
+const releaseCycleURL = "https://peps.python.org/api/release-cycle.json"
+
+func (p *Python) FetchLifecycles(ctx context.Context) ([]*core.Lifecycle, error) {
+	body, err := gvmhttp.Default().Get(ctx, releaseCycleURL)
+	if err != nil {
+		return nil, err
+	}
+	return parseReleaseCycle(body)
+}
+
+// parseReleaseCycle 解析 release-cycle.json，尚未确定具体日期的 end_of_life 只精确到月份，按该月最后一天处理
+func parseReleaseCycle(body []byte) ([]*core.Lifecycle, error) {
+	cycles := make(map[string]struct {
+		EndOfLife string `json:"end_of_life"`
+	})
+	if err := json.Unmarshal(body, &cycles); err != nil {
+		return nil, err
+	}
+	res := make([]*core.Lifecycle, 0, len(cycles))
+	for cycle, v := range cycles {
+		end, err := time.Parse(time.DateOnly, v.EndOfLife)
+		if err != nil {
+			month, err := time.Parse("2006-01", v.EndOfLife)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse end_of_life of %s: %w", cycle, err)
+			}
+			end = month.AddDate(0, 1, -1)
+		}
+		res = append(res, &core.Lifecycle{Cycle: cycle, EOL: end})
+	}
+	eol.Sort(res)
+	return res, nil
 }
 
 func init() {
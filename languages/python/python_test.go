@@ -20,6 +20,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/toodofun/gvm/internal/core"
 	"github.com/toodofun/gvm/languages"
@@ -336,3 +337,23 @@ func TestPython_PreReleaseError_Integration(t *testing.T) {
 		t.Skipf("Install test skipped due to error: %v", err)
 	}
 }
+
+func TestParseReleaseCycle(t *testing.T) {
+	body := []byte(`{
+		"3.14": {"branch": "main", "status": "feature", "first_release": "2025-10-01", "end_of_life": "2030-10"},
+		"3.8": {"branch": "3.8", "status": "end-of-life", "first_release": "2019-10-14", "end_of_life": "2024-10-07"},
+		"3.10": {"branch": "3.10", "status": "security", "first_release": "2021-10-04", "end_of_life": "2026-10"}
+	}`)
+	cycles, err := parseReleaseCycle(body)
+	if err != nil {
+		t.Fatalf("parseReleaseCycle() error = %v", err)
+	}
+	got := make([]string, 0, len(cycles))
+	for _, c := range cycles {
+		got = append(got, c.Cycle+"="+c.EOL.Format(time.DateOnly))
+	}
+	want := "3.8=2024-10-07 3.10=2026-10-31 3.14=2030-10-31"
+	if strings.Join(got, " ") != want {
+		t.Errorf("parseReleaseCycle() = %v, want %v", strings.Join(got, " "), want)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/toodofun/gvm/internal/core"
+)
+
+// Instance 可以自行部署的代码托管服务（如 GitLab、Gitea）的实例地址和 token，环境变量优先于配置文件
+type Instance struct {
+	DefaultURL string
+	URLEnv     string
+	TokenEnv   string
+	// Config 返回配置文件中的实例地址和 token
+	Config func(config *core.Config) (url, token string)
+	// Header 为携带 token 的请求头，Scheme 不为空时作为 token 的前缀，如 Gitea 的 Authorization: token <token>
+	Header string
+	Scheme string
+}
+
+// configuredURL 返回通过环境变量或配置文件指定的实例地址，未指定时为空
+func (i *Instance) configuredURL() string {
+	if custom := os.Getenv(i.URLEnv); custom != "" {
+		return strings.TrimSuffix(custom, "/")
+	}
+	if u, _ := i.Config(core.GetConfig()); u != "" {
+		return strings.TrimSuffix(u, "/")
+	}
+	return ""
+}
+
+// URL 返回实例地址，未指定时为 DefaultURL
+func (i *Instance) URL() string {
+	if u := i.configuredURL(); u != "" {
+		return u
+	}
+	return i.DefaultURL
+}
+
+// Token 返回访问实例使用的 token
+func (i *Instance) Token() string {
+	if token := os.Getenv(i.TokenEnv); token != "" {
+		return token
+	}
+	_, token := i.Config(core.GetConfig())
+	return token
+}
+
+// Headers 返回访问 rawURL 时需要携带的认证请求头，base 为插件所在实例的地址。
+// token 只发送给 base 所在的主机；指定了实例地址时 token 属于该实例，base 在其他主机上时匿名访问
+func (i *Instance) Headers(base, rawURL string) map[string]string {
+	token := i.Token()
+	if token == "" || hostOf(rawURL) != hostOf(base) {
+		return nil
+	}
+	if configured := i.configuredURL(); configured != "" && hostOf(configured) != hostOf(base) {
+		return nil
+	}
+	if i.Scheme != "" {
+		token = i.Scheme + " " + token
+	}
+	return map[string]string{i.Header: token}
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Host)
+}
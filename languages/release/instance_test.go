@@ -0,0 +1,53 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/toodofun/gvm/internal/core"
+)
+
+func TestInstance(t *testing.T) {
+	instance := &Instance{
+		DefaultURL: "https://forge.example.org",
+		URLEnv:     "GVM_TEST_FORGE_URL",
+		TokenEnv:   "TEST_FORGE_TOKEN",
+		Config: func(config *core.Config) (string, string) {
+			return "", ""
+		},
+		Header: "Authorization",
+		Scheme: "token",
+	}
+	base := "https://git.example.com"
+
+	assert.Equal(t, "https://forge.example.org", instance.URL())
+	assert.Nil(t, instance.Headers(base, base+"/api/v1/repos"), "no token configured")
+
+	t.Setenv("TEST_FORGE_TOKEN", "secret")
+	want := map[string]string{"Authorization": "token secret"}
+	// 没有指定实例地址时，token 发送给数据源所在的主机
+	assert.Equal(t, want, instance.Headers(base, base+"/api/v1/repos"))
+	assert.Equal(t, want, instance.Headers(base, "https://GIT.example.com/org/repo/releases/download/v1/tool.zip"))
+	assert.Nil(t, instance.Headers(base, "https://objects.example.net/tool.zip"), "other hosts are anonymous")
+
+	t.Setenv("GVM_TEST_FORGE_URL", "https://git.example.com/")
+	assert.Equal(t, "https://git.example.com", instance.URL())
+	assert.Equal(t, want, instance.Headers(base, base+"/api/v1/repos"))
+	assert.Nil(t, instance.Headers("https://codeberg.org", "https://codeberg.org/api/v1/repos"),
+		"the token belongs to the configured instance")
+}
@@ -0,0 +1,310 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package release 实现基于发布版本列表的插件语言，GitHub、GitLab 等插件只需要提供发布版本及其安装包
+package release
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/http"
+	"github.com/toodofun/gvm/internal/log"
+	"github.com/toodofun/gvm/internal/util/asset"
+	"github.com/toodofun/gvm/internal/util/env"
+	"github.com/toodofun/gvm/internal/util/path"
+	"github.com/toodofun/gvm/languages"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// downloadDir 安装包的下载目录，位于 gvm 根目录下；下载中断时保留已下载的部分，下次安装时断点续传
+const downloadDir = ".cache/release"
+
+// Release 插件的一个发布版本，Name 同时作为版本的原始名称和安装包模板中的 {version}
+type Release struct {
+	Name       string
+	Prerelease bool
+	Assets     []core.Asset
+}
+
+// Source 插件的数据源，返回所有发布版本
+type Source interface {
+	ListReleases(ctx context.Context) ([]*Release, error)
+}
+
+// AssetLister 可选接口，数据源列出版本时不返回安装包（如每个版本位于单独的目录），
+// 选择安装包时再获取
+type AssetLister interface {
+	ListAssets(ctx context.Context, release *Release) ([]core.Asset, error)
+}
+
+// Authenticator 可选接口，数据源为下载安装包提供请求头，如私有仓库的 token
+type Authenticator interface {
+	Headers(url string) map[string]string
+}
+
+// Addon 基于发布版本列表的插件语言，负责安装包选择、下载解压和环境变量
+type Addon struct {
+	name       string
+	executable string
+	source     Source
+	rules      *asset.Rules
+
+	// releases 为最近一次列出的发布版本，选择安装包时复用，避免重复请求数据源
+	mu       sync.Mutex
+	releases []*Release
+}
+
+// NewAddon 创建插件语言，executable 为没有声明 bin 时的可执行文件名
+func NewAddon(name, executable string, source Source, rules *asset.Rules) *Addon {
+	return &Addon{name: name, executable: executable, source: source, rules: rules}
+}
+
+func (a *Addon) Name() string {
+	return a.name
+}
+
+func (a *Addon) ListRemoteVersions(ctx context.Context) ([]*core.RemoteVersion, error) {
+	logger := log.GetLogger(ctx)
+	res := make([]*core.RemoteVersion, 0)
+
+	releases, err := a.source.ListReleases(ctx)
+	if err != nil {
+		logger.Errorf("get releases error: %s", err)
+		return nil, err
+	}
+	a.mu.Lock()
+	a.releases = releases
+	a.mu.Unlock()
+
+	for _, release := range releases {
+		comment := "Stable Release"
+		if release.Prerelease {
+			comment = "Prerelease"
+		}
+
+		ver, err := goversion.NewVersion(strings.TrimPrefix(release.Name, "v"))
+		if err != nil {
+			logger.Warnf("Failed to parse version %s: %v", release.Name, err)
+			continue
+		}
+		res = append(res, &core.RemoteVersion{
+			Version: ver,
+			Origin:  release.Name,
+			Comment: comment,
+		})
+	}
+	return res, nil
+}
+
+func (a *Addon) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVersion, error) {
+	return languages.NewLanguage(a).ListInstalledVersions(ctx, filepath.Join())
+}
+
+func (a *Addon) SetDefaultVersion(ctx context.Context, version string) error {
+	return languages.NewLanguage(a).SetDefaultVersion(ctx, version, a.Envs())
+}
+
+// Envs 声明了 bin 时只把 current/bin 加入 PATH，否则无法得知可执行文件的位置，current 也会加入 PATH
+func (a *Addon) Envs() []env.KV {
+	bin := env.KV{
+		Key:    "PATH",
+		Value:  filepath.Join(path.GetLangRoot(a.Name()), path.Current, "bin"),
+		Append: true,
+	}
+	if len(a.rules.Layout.Bins) > 0 {
+		return []env.KV{bin}
+	}
+	return []env.KV{
+		{
+			Key:    "PATH",
+			Value:  filepath.Join(path.GetLangRoot(a.Name()), path.Current),
+			Append: true,
+		},
+		bin,
+	}
+}
+
+func (a *Addon) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
+	return languages.NewLanguage(a).GetDefaultVersion()
+}
+
+func (a *Addon) Install(ctx context.Context, remoteVersion *core.RemoteVersion) error {
+	logger := log.GetLogger(ctx)
+	logger.Infof("Install remote version %s", remoteVersion.Origin)
+	lang := a.Name()
+	if err, exist := languages.HasInstall(ctx, a, *remoteVersion.Version); err != nil || exist {
+		return err
+	}
+
+	selected, _, err := a.ResolveAsset(ctx, remoteVersion)
+	if err != nil {
+		return err
+	}
+	url, name := selected.URL, selected.Name
+	var header map[string]string
+	if auth, ok := a.source.(Authenticator); ok {
+		header = auth.Headers(url)
+	}
+
+	head, code, err := http.Default().HeadWithHeader(ctx, url, header)
+	if err != nil {
+		logger.Errorf("Head remote version error: %v", err)
+		return err
+	}
+	if code != 200 {
+		logger.Warnf("Head remote version code: %d", code)
+		return fmt.Errorf("version %s not found", remoteVersion.Version.String())
+	}
+
+	// 下载到版本目录之外，下载失败时不会留下版本目录，已下载的部分用于断点续传
+	download := filepath.Join(core.GetRootDir(), downloadDir, lang, remoteVersion.Version.String())
+	logger.Infof("Downloading %s size: %s", url, head.Get("Content-Length"))
+	file, err := http.Default().DownloadWithHeader(ctx, url, download, name, header)
+	logger.Infof("")
+	if err != nil {
+		logger.Errorf("Download remote version error: %v", err)
+		return fmt.Errorf("failed to download version %s: %w", remoteVersion.Version.String(), err)
+	}
+
+	dest := filepath.Join(path.GetLangRoot(lang), remoteVersion.Version.String())
+	err = a.rules.Layout.Install(ctx, file, dest, a.Executable())
+	// 安装包已经完整下载，无论解压是否成功都删除，损坏的安装包不能被当作已下载完成而复用
+	if rmErr := os.RemoveAll(download); rmErr != nil {
+		logger.Warnf("Failed to clean %s: %v", download, rmErr)
+	}
+	if err != nil {
+		logger.Warnf("Failed to extract version %s: %s", remoteVersion.Version.String(), err)
+		cleanDest(ctx, dest)
+		return fmt.Errorf("failed to extract version %s: %w", remoteVersion.Version.String(), err)
+	}
+
+	logger.Infof("Version %s was successfully installed in %s", remoteVersion.Version.String(), dest)
+	return nil
+}
+
+// cleanDest 删除安装失败的版本目录，避免残留的目录被当作已安装的版本
+func cleanDest(ctx context.Context, dest string) {
+	if err := os.RemoveAll(dest); err != nil {
+		log.GetLogger(ctx).Warnf("Failed to clean %s: %v", dest, err)
+	}
+}
+
+// ResolveAsset 按数据源中的规则选择当前平台的安装包，macOS 上找不到 arm64 版本时退回 amd64；
+// 已经列出过发布版本时直接使用，不再请求数据源
+func (a *Addon) ResolveAsset(
+	ctx context.Context,
+	remoteVersion *core.RemoteVersion,
+) (*core.Asset, []core.AssetRejection, error) {
+	logger := log.GetLogger(ctx)
+	a.mu.Lock()
+	releases := a.releases
+	a.mu.Unlock()
+	if releases == nil {
+		var err error
+		if releases, err = a.source.ListReleases(ctx); err != nil {
+			logger.Errorf("Get remote versions error: %v", err)
+			return nil, nil, err
+		}
+	}
+
+	var record *Release
+	for _, release := range releases {
+		if release.Name == remoteVersion.Origin {
+			record = release
+			break
+		}
+	}
+	if record == nil {
+		logger.Errorf("Release %s not found", remoteVersion.Origin)
+		return nil, nil, fmt.Errorf("remote version %s not found", remoteVersion.Origin)
+	}
+	assets := record.Assets
+	if lister, ok := a.source.(AssetLister); ok && len(assets) == 0 {
+		var err error
+		if assets, err = lister.ListAssets(ctx, record); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	names := make([]string, 0, len(assets))
+	urls := make(map[string]string, len(assets))
+	for _, item := range assets {
+		names = append(names, item.Name)
+		urls[item.Name] = item.URL
+	}
+	name, rejections := a.rules.Select(names, remoteVersion.Origin, runtime.GOOS, runtime.GOARCH)
+	if name == "" && runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" {
+		name, _ = a.rules.Select(names, remoteVersion.Origin, runtime.GOOS, "amd64")
+	}
+	if name == "" {
+		return nil, rejections, fmt.Errorf("no asset of %s matches %s/%s", remoteVersion.Origin, runtime.GOOS, runtime.GOARCH)
+	}
+	return &core.Asset{Name: name, URL: urls[name]}, rejections, nil
+}
+
+func (a *Addon) InstallFromFile(ctx context.Context, remoteVersion *core.RemoteVersion, file string) error {
+	if err, exist := languages.HasInstall(ctx, a, *remoteVersion.Version); err != nil || exist {
+		return err
+	}
+	dest := filepath.Join(path.GetLangRoot(a.Name()), remoteVersion.Version.String())
+	if err := a.rules.Layout.Install(ctx, file, dest, a.Executable()); err != nil {
+		cleanDest(ctx, dest)
+		return fmt.Errorf("failed to extract version %s: %w", remoteVersion.Version.String(), err)
+	}
+	log.GetLogger(ctx).Infof("Version %s was successfully installed in %s", remoteVersion.Version.String(), dest)
+	return nil
+}
+
+func (a *Addon) InstallFromDir(ctx context.Context, remoteVersion *core.RemoteVersion, dir string) error {
+	if err, exist := languages.HasInstall(ctx, a, *remoteVersion.Version); err != nil || exist {
+		return err
+	}
+	return languages.NewLanguage(a).InstallFromDir(ctx, remoteVersion.Version.String(), dir, "")
+}
+
+// Link 登记外部安装的工具，无法得知可执行文件名，需要显式指定版本
+func (a *Addon) Link(ctx context.Context, name, target string, version *goversion.Version) (*core.InstalledVersion, error) {
+	if version == nil {
+		return nil, fmt.Errorf("can not detect version of %s, please specify it explicitly", a.Name())
+	}
+	return languages.NewLanguage(a).Link(ctx, name, target, "", version)
+}
+
+func (a *Addon) Uninstall(ctx context.Context, version string) error {
+	return languages.NewLanguage(a).Uninstall(ctx, version, a.Envs())
+}
+
+func (a *Addon) UnsetDefaultVersion(ctx context.Context) error {
+	return languages.NewLanguage(a).UnsetDefaultVersion(ctx, a.Envs())
+}
+
+// Executable 返回可执行文件名，声明了 bin 时为第一个可执行文件，否则为数据源给出的名称（如仓库名）
+func (a *Addon) Executable() string {
+	if len(a.rules.Layout.Bins) > 0 {
+		b := a.rules.Layout.Bins[0]
+		if b.Name != "" {
+			return b.Name
+		}
+		return filepath.Base(b.Path)
+	}
+	return a.executable
+}
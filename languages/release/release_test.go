@@ -0,0 +1,285 @@
+// Copyright 2025 The Toodofun Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http:www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package release
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/testutil"
+	"github.com/toodofun/gvm/internal/util/asset"
+)
+
+// fakeSource 固定的发布版本列表，token 不为空时下载需要认证
+type fakeSource struct {
+	releases []*Release
+	// assets 不为空时实现 AssetLister，版本列表中不带安装包
+	assets map[string][]core.Asset
+	token  string
+}
+
+func (f *fakeSource) ListReleases(ctx context.Context) ([]*Release, error) {
+	return f.releases, nil
+}
+
+type listerSource struct {
+	*fakeSource
+}
+
+func (l *listerSource) ListAssets(ctx context.Context, release *Release) ([]core.Asset, error) {
+	return l.assets[release.Name], nil
+}
+
+type authSource struct {
+	*fakeSource
+}
+
+func (a *authSource) Headers(url string) map[string]string {
+	return map[string]string{"Authorization": "token " + a.token}
+}
+
+func platformAsset(version string) string {
+	return fmt.Sprintf("tool-%s-%s-%s.zip", version, runtime.GOOS, runtime.GOARCH)
+}
+
+// newFakeServer 提供 /dl/<name> 下载，压缩包中的文件位于 tool/ 目录下
+func newFakeServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && r.Header.Get("Authorization") != "token "+token {
+			http.NotFound(w, r)
+			return
+		}
+		if filepath.Ext(r.URL.Path) != ".zip" {
+			http.NotFound(w, r)
+			return
+		}
+		if filepath.Base(r.URL.Path) == platformAsset("0.9.0") {
+			_, _ = w.Write([]byte("broken"))
+			return
+		}
+		zw := zip.NewWriter(w)
+		f, _ := zw.Create("tool/bin/tool")
+		_, _ = f.Write([]byte("binary"))
+		_ = zw.Close()
+	}))
+}
+
+func TestAddon_ListRemoteVersions(t *testing.T) {
+	source := &fakeSource{releases: []*Release{
+		{Name: "v1.0.0"},
+		{Name: "nightly"},
+		{Name: "v1.1.0-rc.1", Prerelease: true},
+	}}
+	versions, err := NewAddon("tool", "tool", source, asset.DefaultRules()).ListRemoteVersions(context.Background())
+	require.NoError(t, err)
+	require.Len(t, versions, 2, "nightly is not a version")
+	assert.Equal(t, "v1.0.0", versions[0].Origin)
+	assert.Equal(t, "1.0.0", versions[0].Version.String())
+	assert.Equal(t, "Stable Release", versions[0].Comment)
+	assert.Equal(t, "Prerelease", versions[1].Comment)
+}
+
+func TestAddon_ResolveAsset(t *testing.T) {
+	ctx := context.Background()
+	name := platformAsset("1.0.0")
+	assets := []core.Asset{
+		{Name: name, URL: "https://example.com/" + name},
+		{Name: "tool-1.0.0-plan9-amd64.zip", URL: "https://example.com/plan9"},
+	}
+
+	a := NewAddon("tool", "tool", &fakeSource{releases: []*Release{{Name: "v1.0.0", Assets: assets}}}, asset.DefaultRules())
+	versions, err := a.ListRemoteVersions(ctx)
+	require.NoError(t, err)
+	selected, rejected, err := a.ResolveAsset(ctx, versions[0])
+	require.NoError(t, err)
+	assert.Equal(t, &core.Asset{Name: name, URL: "https://example.com/" + name}, selected)
+	require.Len(t, rejected, 1)
+	assert.Equal(t, "tool-1.0.0-plan9-amd64.zip", rejected[0].Name)
+
+	// 同名的发布版本使用第一个
+	dup := NewAddon("tool", "tool", &fakeSource{releases: []*Release{
+		{Name: "v1.0.0", Assets: assets},
+		{Name: "v1.0.0", Assets: []core.Asset{{Name: name, URL: "https://example.com/stale"}}},
+	}}, asset.DefaultRules())
+	selected, _, err = dup.ResolveAsset(ctx, versions[0])
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/"+name, selected.URL)
+
+	// 版本列表中没有安装包时通过 AssetLister 获取
+	lister := &listerSource{&fakeSource{
+		releases: []*Release{{Name: "v1.0.0"}},
+		assets:   map[string][]core.Asset{"v1.0.0": assets},
+	}}
+	selected, _, err = NewAddon("tool", "tool", lister, asset.DefaultRules()).ResolveAsset(ctx, versions[0])
+	require.NoError(t, err)
+	assert.Equal(t, name, selected.Name)
+
+	empty := NewAddon("tool", "tool", &fakeSource{releases: []*Release{{Name: "v1.0.0"}}}, asset.DefaultRules())
+	_, _, err = empty.ResolveAsset(ctx, versions[0])
+	assert.ErrorContains(t, err, "no asset of v1.0.0 matches")
+	versions[0].Origin = "v9.9.9"
+	_, _, err = empty.ResolveAsset(ctx, versions[0])
+	assert.ErrorContains(t, err, "remote version v9.9.9 not found")
+}
+
+func TestAddon_Install(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+	root := testutil.SetRootDir(t)
+	server := newFakeServer(t, "secret")
+	defer server.Close()
+	ctx := context.Background()
+
+	name, broken := platformAsset("1.0.0"), platformAsset("0.9.0")
+	source := &authSource{&fakeSource{
+		releases: []*Release{
+			{Name: "v1.0.0", Assets: []core.Asset{{Name: name, URL: server.URL + "/dl/" + name}}},
+			{Name: "v0.9.0", Assets: []core.Asset{{Name: broken, URL: server.URL + "/dl/" + broken}}},
+		},
+		token: "secret",
+	}}
+	rules, err := asset.ParseRules("strip=1&bin=bin/tool")
+	require.NoError(t, err)
+	a := NewAddon("tool", "tool", source, rules)
+	versions, err := a.ListRemoteVersions(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, a.Install(ctx, versions[0]))
+	data, err := os.ReadFile(filepath.Join(root, "tool", "1.0.0", "bin", "tool"))
+	require.NoError(t, err)
+	assert.Equal(t, "binary", string(data))
+	assert.NoFileExists(t, filepath.Join(root, "tool", "1.0.0", name), "the archive is removed after extracting")
+
+	installed, err := a.ListInstalledVersions(ctx)
+	require.NoError(t, err)
+	require.Len(t, installed, 1)
+	assert.Equal(t, "1.0.0", installed[0].Version.String())
+
+	// 解压失败时不留下版本目录
+	assert.ErrorContains(t, a.Install(ctx, versions[1]), "failed to extract version 0.9.0")
+	assert.NoDirExists(t, filepath.Join(root, "tool", "0.9.0"))
+	// 本地安装包可以解压但缺少 bin/tool，已解压的内容同样被删除
+	archive, err := os.Create(filepath.Join(t.TempDir(), broken))
+	require.NoError(t, err)
+	zw := zip.NewWriter(archive)
+	_, err = zw.Create("tool/README")
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, archive.Close())
+	assert.ErrorContains(t, a.InstallFromFile(ctx, versions[1], archive.Name()), "failed to extract version 0.9.0")
+	assert.NoDirExists(t, filepath.Join(root, "tool", "0.9.0"))
+
+	// 下载需要的请求头来自 Authenticator，缺少 token 时服务器返回 404；已安装的版本不会再次下载
+	source.token = "wrong"
+	require.NoError(t, a.Install(ctx, versions[0]))
+	require.NoError(t, a.Uninstall(ctx, "1.0.0"))
+	assert.ErrorContains(t, a.Install(ctx, versions[0]), "version 1.0.0 not found")
+}
+
+func TestAddon_InstallResume(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+	root := testutil.SetRootDir(t)
+	ctx := context.Background()
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	f, err := zw.Create("tool/bin/tool")
+	require.NoError(t, err)
+	_, err = f.Write(bytes.Repeat([]byte("binary"), 1024))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	content := archive.Bytes()
+
+	// 第一次完整下载只返回一半内容后断开连接，之后的请求按 Range 返回
+	var ranges []string
+	interrupted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			ranges = append(ranges, r.Header.Get("Range"))
+			if r.Header.Get("Range") == "" && !interrupted {
+				interrupted = true
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				_, _ = w.Write(content[:len(content)/2])
+				w.(http.Flusher).Flush()
+				panic(http.ErrAbortHandler)
+			}
+		}
+		http.ServeContent(w, r, "tool.zip", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	name := platformAsset("1.0.0")
+	source := &fakeSource{releases: []*Release{
+		{Name: "v1.0.0", Assets: []core.Asset{{Name: name, URL: server.URL + "/dl/" + name}}},
+	}}
+	rules, err := asset.ParseRules("strip=1&bin=bin/tool")
+	require.NoError(t, err)
+	a := NewAddon("tool", "tool", source, rules)
+	versions, err := a.ListRemoteVersions(ctx)
+	require.NoError(t, err)
+
+	// 下载失败时不留下版本目录，已下载的部分保留在下载目录中
+	assert.ErrorContains(t, a.Install(ctx, versions[0]), "failed to download version 1.0.0")
+	assert.NoDirExists(t, filepath.Join(root, "tool", "1.0.0"))
+	partial, err := os.Stat(filepath.Join(root, downloadDir, "tool", "1.0.0", name))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)/2), partial.Size())
+
+	require.NoError(t, a.Install(ctx, versions[0]))
+	assert.Equal(t, []string{"", fmt.Sprintf("bytes=%d-", len(content)/2)}, ranges)
+	data, err := os.ReadFile(filepath.Join(root, "tool", "1.0.0", "bin", "tool"))
+	require.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte("binary"), 1024), data)
+	assert.NoDirExists(t, filepath.Join(root, downloadDir, "tool", "1.0.0"))
+}
+
+func TestAddon_Envs(t *testing.T) {
+	root := testutil.SetRootDir(t)
+	current := filepath.Join(root, "tool", "current")
+
+	a := NewAddon("tool", "repo", &fakeSource{}, asset.DefaultRules())
+	envs := a.Envs()
+	require.Len(t, envs, 2, "without bin both current and current/bin are added to PATH")
+	assert.Equal(t, current, envs[0].Value)
+	assert.Equal(t, filepath.Join(current, "bin"), envs[1].Value)
+	assert.Equal(t, "repo", a.Executable())
+
+	rules, err := asset.ParseRules("bin=dist/mytool")
+	require.NoError(t, err)
+	a = NewAddon("tool", "repo", &fakeSource{}, rules)
+	envs = a.Envs()
+	require.Len(t, envs, 1)
+	assert.Equal(t, filepath.Join(current, "bin"), envs[0].Value)
+	assert.True(t, envs[0].Append)
+	assert.Equal(t, "mytool", a.Executable())
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 
@@ -76,7 +77,11 @@ func (r *Ruby) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVers
 }
 
 func (r *Ruby) SetDefaultVersion(ctx context.Context, version string) error {
-	envs := []env.KV{
+	return languages.NewLanguage(r).SetDefaultVersion(ctx, version, r.Envs())
+}
+
+func (r *Ruby) Envs() []env.KV {
+	return []env.KV{
 		{
 			Key:    "PATH",
 			Value:  filepath.Join(path.GetLangRoot(r.Name()), path.Current, "bin"),
@@ -92,7 +97,6 @@ func (r *Ruby) SetDefaultVersion(ctx context.Context, version string) error {
 			Append: true,
 		},
 	}
-	return languages.NewLanguage(r).SetDefaultVersion(ctx, version, envs)
 }
 
 func (r *Ruby) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
@@ -100,7 +104,15 @@ func (r *Ruby) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
 }
 
 func (r *Ruby) Uninstall(ctx context.Context, version string) error {
-	return languages.NewLanguage(r).Uninstall(version)
+	return languages.NewLanguage(r).Uninstall(ctx, version, r.Envs())
+}
+
+func (r *Ruby) UnsetDefaultVersion(ctx context.Context) error {
+	return languages.NewLanguage(r).UnsetDefaultVersion(ctx, r.Envs())
+}
+
+func (r *Ruby) Executable() string {
+	return "ruby"
 }
 
 func (r *Ruby) Install(ctx context.Context, version *core.RemoteVersion) error {
@@ -201,6 +213,20 @@ func (r *Ruby) getDownloadURL(version string) (string, string, error) {
 	}
 }
 
+var versionPattern = regexp.MustCompile(`ruby (\d+\.\d+\.\d+)`)
+
+// Link 登记外部安装的 Ruby（如 rbenv 的版本目录），target 为包含 bin/ruby 的安装前缀
+func (r *Ruby) Link(ctx context.Context, name, target string, version *goversion.Version) (*core.InstalledVersion, error) {
+	if version == nil {
+		var err error
+		version, err = languages.DetectVersion(ctx, filepath.Join(target, "bin", "ruby"), []string{"--version"}, versionPattern)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return languages.NewLanguage(r).Link(ctx, name, target, "", version)
+}
+
 func init() {
 	core.RegisterLanguage(&Ruby{})
 }
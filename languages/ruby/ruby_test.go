@@ -16,13 +16,18 @@ package ruby
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/toodofun/gvm/internal/core"
+	"github.com/toodofun/gvm/internal/testutil"
 
 	goversion "github.com/hashicorp/go-version"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRuby_Name(t *testing.T) {
@@ -220,3 +225,27 @@ func TestRuby_VersionParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestRuby_Link(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlink test skipped on Windows")
+	}
+	testutil.SetRootDir(t)
+
+	// 模拟 rbenv 安装的版本目录
+	target := filepath.Join(t.TempDir(), "3.2.2")
+	require.NoError(t, os.MkdirAll(filepath.Join(target, "bin"), 0755))
+	script := "#!/bin/sh\necho 'ruby 3.2.2 (2023-03-30 revision e51014f9c0) [x86_64-linux]'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(target, "bin", "ruby"), []byte(script), 0755))
+
+	r := &Ruby{}
+	ctx := context.Background()
+	iv, err := r.Link(ctx, "rbenv", target, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "3.2.2+rbenv", iv.Version.String())
+
+	installed, err := r.ListInstalledVersions(ctx)
+	require.NoError(t, err)
+	require.Len(t, installed, 1)
+	assert.Equal(t, "3.2.2+rbenv", installed[0].Version.String())
+}
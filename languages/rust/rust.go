@@ -38,7 +38,7 @@ import (
 
 const (
 	lang              = "rust"
-	githubReleasesURL = "https://api.github.com/repos/rust-lang/rust/releases"
+	githubReleasesURL = gvmhttp.PublicGitHubAPIURL + "/repos/rust-lang/rust/releases"
 	downloadBaseURL   = "https://static.rust-lang.org/dist/"
 	preRelease        = "Pre-release"
 	stableRelease     = "Stable Release"
@@ -63,7 +63,7 @@ func (r *Rust) ListRemoteVersions(ctx context.Context) ([]*core.RemoteVersion, e
 	res := make([]*core.RemoteVersion, 0)
 
 	// 从 GitHub API 获取发布版本
-	body, err := gvmhttp.Default().Get(ctx, githubReleasesURL+"?per_page=100")
+	body, _, err := gvmhttp.Default().GetGitHub(ctx, githubReleasesURL+"?per_page=100", "")
 	if err != nil {
 		logger.Warnf("Failed to fetch rust versions from GitHub: %v", err)
 		return res, err
@@ -114,7 +114,11 @@ func (r *Rust) ListInstalledVersions(ctx context.Context) ([]*core.InstalledVers
 }
 
 func (r *Rust) SetDefaultVersion(ctx context.Context, version string) error {
-	envs := []env.KV{
+	return languages.NewLanguage(r).SetDefaultVersion(ctx, version, r.Envs())
+}
+
+func (r *Rust) Envs() []env.KV {
+	return []env.KV{
 		{
 			Key:    "PATH",
 			Value:  filepath.Join(path.GetLangRoot(r.Name()), path.Current, "bin"),
@@ -129,7 +133,6 @@ func (r *Rust) SetDefaultVersion(ctx context.Context, version string) error {
 			Value: filepath.Join(path.GetLangRoot(r.Name()), path.Current, "cargo"),
 		},
 	}
-	return languages.NewLanguage(r).SetDefaultVersion(ctx, version, envs)
 }
 
 func (r *Rust) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
@@ -137,7 +140,15 @@ func (r *Rust) GetDefaultVersion(ctx context.Context) *core.InstalledVersion {
 }
 
 func (r *Rust) Uninstall(ctx context.Context, version string) error {
-	return languages.NewLanguage(r).Uninstall(version)
+	return languages.NewLanguage(r).Uninstall(ctx, version, r.Envs())
+}
+
+func (r *Rust) UnsetDefaultVersion(ctx context.Context) error {
+	return languages.NewLanguage(r).UnsetDefaultVersion(ctx, r.Envs())
+}
+
+func (r *Rust) Executable() string {
+	return "rustc"
 }
 
 func (r *Rust) Install(ctx context.Context, version *core.RemoteVersion) error {
@@ -15,17 +15,25 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/toodofun/gvm/cmd"
+	"github.com/toodofun/gvm/internal/core"
+	_ "github.com/toodofun/gvm/languages/asdf"
+	"github.com/toodofun/gvm/languages/declarative"
+	_ "github.com/toodofun/gvm/languages/dirindex"
+	_ "github.com/toodofun/gvm/languages/gitea"
 	_ "github.com/toodofun/gvm/languages/github"
+	_ "github.com/toodofun/gvm/languages/gitlab"
 	_ "github.com/toodofun/gvm/languages/golang"
 	_ "github.com/toodofun/gvm/languages/gvm"
 	_ "github.com/toodofun/gvm/languages/java"
 	_ "github.com/toodofun/gvm/languages/node"
+	"github.com/toodofun/gvm/languages/plugin"
 	_ "github.com/toodofun/gvm/languages/python"
+	_ "github.com/toodofun/gvm/languages/ruby"
 
-	//_ "github.com/toodofun/gvm/languages/ruby"
 	//_ "github.com/toodofun/gvm/languages/rust"
 
 	"github.com/sirupsen/logrus"
@@ -44,11 +52,20 @@ import (
 func main() {
 	//initI18n()
 
+	// 内置语言在各包的 init 中注册，之后加载的语言与已注册的语言重名时不会注册
+	core.LoadAddons()
+	declarative.Load()
+	plugin.Load()
 	root := cmd.NewRootCmd()
 	if len(os.Args) == 1 {
 		os.Args = append(os.Args, "ui")
 	}
 	if err := root.Execute(); err != nil {
+		// gvm exec 执行的命令失败时已经输出了错误信息，只需使用相同的退出码
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		logrus.Fatalf("%v", err)
 	}
 }